@@ -3,6 +3,7 @@ package asana // import "github.com/andoma-go/asana-go"
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,9 +13,11 @@ import (
 	"net/textproto"
 	"net/url"
 	"os"
+	"runtime/debug"
 	"strings"
+	"sync"
+	"time"
 
-	"dario.cat/mergo"
 	"github.com/google/go-querystring/query"
 	"github.com/pkg/errors"
 	"github.com/rs/xid"
@@ -23,8 +26,49 @@ import (
 const (
 	// BaseURL is the default URL used to access the Asana API
 	BaseURL = "https://app.asana.com/api/1.0"
+
+	// AppURL is the URL of the Asana web app, used to construct links to objects
+	AppURL = "https://app.asana.com"
 )
 
+var (
+	// Version is the version of this client library, as recorded in the
+	// build information of the program using it, e.g. v1.2.3. It is
+	// "(devel)" when the version is unknown, such as when the library is
+	// built as the main module or replaced by a local directory.
+	Version = moduleVersion()
+
+	// DefaultUserAgent is sent with requests when Client.UserAgent is not set
+	DefaultUserAgent = "asana-go/" + Version
+)
+
+// modulePath is the import path of this library
+const modulePath = "github.com/andoma-go/asana-go"
+
+// moduleVersion returns the version of this library from the build
+// information of the running program
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		if dep.Version != "" {
+			return dep.Version
+		}
+	}
+	return "(devel)"
+}
+
 type Feature string
 
 func (f Feature) String() string {
@@ -39,14 +83,84 @@ const (
 
 // Client is the root client for the Asana API. The nested HTTPClient should provide
 // Authorization header injection.
+//
+// Every request acts as the user who owns the token. The API has no way to
+// impersonate another user, even for enterprise service accounts, so
+// integrations acting for many users need a token for each of them, e.g.
+// obtained through OAuth.
 type Client struct {
 	BaseURL    *url.URL
 	HTTPClient *http.Client
 
 	Verbose        []bool
 	DefaultOptions Options
+
+	// DownloadClient is used to download attachments and organization
+	// exports from their pre-signed URLs. It must not add the credentials
+	// of HTTPClient, which the storage service rejects. Downloads are served
+	// by the storage service rather than the API, so they do not count
+	// towards RateLimit or the circuit breaker. Defaults to a client which
+	// gives up on a download after 30 minutes.
+	DownloadClient *http.Client
+
+	// UserAgent is sent in the User-Agent header of every request, so that
+	// Asana can attribute traffic to an application. Defaults to
+	// DefaultUserAgent.
+	UserAgent string
+
+	// RetryJitter selects how the delay between retries is randomized.
+	// Defaults to JitterNone.
+	RetryJitter Jitter
+
+	// CircuitThreshold is the number of consecutive rate limit, server or
+	// transport errors after which the client stops sending requests, and
+	// fails them with a CircuitOpenError instead, for CircuitCooldown. This
+	// protects a degraded API from bulk jobs. Zero disables the breaker.
+	CircuitThreshold int
+
+	// CircuitCooldown is how long the circuit breaker stays open before
+	// probing the API with a single request. Defaults to 30 seconds.
+	CircuitCooldown time.Duration
+	circuit         circuit
+
+	// RateLimit is the number of requests per minute the client sends at
+	// most, waiting before a request once the budget is spent. This keeps
+	// bulk jobs below Asana's limits, rather than relying on retries after
+	// being rate limited. Zero disables the limiter.
+	RateLimit int
+
+	// RequestCosts overrides the costs returned by DefaultRequestCosts. It
+	// maps endpoint paths, in which * stands for any single segment such as
+	// "/workspaces/*/tasks/search", to the number of requests of the
+	// RateLimit budget a request to the endpoint consumes. Other requests
+	// cost 1. The map must not be modified while the client is in use.
+	RequestCosts map[string]int
+	limiter      rateLimiter
+
+	// Cached current user, see Me
+	meLock sync.Mutex
+	me     *User
+
+	// Cached user IDs by workspace and email address, and the time the
+	// users of each workspace were listed, see UserIDByEmail
+	emailLock   sync.Mutex
+	emailIDs    map[string]string
+	emailListed map[string]time.Time
+
+	// Cached resource IDs by workspace, type and name, see ResolveProject
+	resolveLock sync.Mutex
+	resolved    map[string]resolvedName
+
+	// Changes announced in Asana-Change headers, see Deprecations
+	deprecationLock sync.Mutex
+	deprecations    []Deprecation
+	onDeprecation   func(change, info, affectedURL string)
 }
 
+// defaultDownloadClient is used for pre-signed downloads if
+// Client.DownloadClient is not set
+var defaultDownloadClient = &http.Client{Timeout: 30 * time.Minute}
+
 // NewClient instantiates a new Asana client with the given HTTP client and
 // the default base URL
 func NewClient(httpClient *http.Client) *Client {
@@ -63,10 +177,24 @@ type request struct {
 	Options *Options    `json:"options,omitempty"`
 }
 
+// NextPage identifies the next page of a paginated list. It is returned by
+// list requests when more results are available, and nil on the last page.
+//
+// The offset is an opaque token rather than a position, and the path repeats
+// the query of the original request together with the next offset. The
+// paging helpers request the path as given, or the URI if there is no path,
+// and only fall back to passing the offset in Options.Offset if neither is
+// present.
 type NextPage struct {
+	// An opaque token to pass as Options.Offset
 	Offset string `json:"offset"`
-	Path   string `json:"path"`
-	URI    string `json:"uri"`
+
+	// The path of the next page relative to the API base URL, including its
+	// query
+	Path string `json:"path"`
+
+	// The absolute URL of the next page
+	URI string `json:"uri"`
 }
 
 // An API response
@@ -104,19 +232,8 @@ func (c *Client) get(path string, data, result interface{}, opts ...*Options) (*
 	requestID := xid.New()
 
 	// Prepare options
-	options, err := c.mergeOptions(opts...)
-	if err != nil {
-		return nil, errors.Wrapf(err, "%s unable to merge options", requestID)
-	}
-
-	// Encode default options
-	if IsTrue(options.Debug) {
-		log.Printf("%s Default options: %+v", requestID, c.DefaultOptions)
-	}
-	q, err := query.Values(c.DefaultOptions)
-	if err != nil {
-		return nil, errors.Wrapf(err, "%s Unable to marshal DefaultOptions to query parameters", requestID)
-	}
+	options := c.mergeOptions(opts...)
+	q := url.Values{}
 
 	// Encode data
 	if data != nil {
@@ -136,20 +253,77 @@ func (c *Client) get(path string, data, result interface{}, opts ...*Options) (*
 		}
 	}
 
-	// Encode query options
-	for _, options := range opts {
-		if IsTrue(options.Debug) {
-			log.Printf("%s Options: %+v", requestID, options)
-		}
-		if err := mergeQuery(q, options); err != nil {
-			return nil, err
+	if err := checkFields(result, options); err != nil {
+		return nil, err
+	}
+
+	// Encode query options, which take precedence over data fields
+	if IsTrue(options.Debug) {
+		log.Printf("%s Options: %+v", requestID, options)
+	}
+	if err := mergeQuery(q, options); err != nil {
+		return nil, err
+	}
+	if len(q) > 0 {
+		path = path + "?" + q.Encode()
+	}
+
+	return c.getPath(requestID, path, result, options)
+}
+
+// getNextPage loads the page of results at the path returned in a NextPage.
+// The path is used as given, as it carries the query of the original
+// request and the server's choice of page size and offset. Query options
+// are only added if the path does not already include them.
+func (c *Client) getNextPage(nextPage *NextPage, result interface{}, opts ...*Options) (*NextPage, error) {
+	requestID := xid.New()
+	options := c.mergeOptions(opts...)
+
+	if err := checkFields(result, options); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(c.nextPagePath(nextPage))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s Invalid next page path", requestID)
+	}
+
+	q := u.Query()
+	defaults := url.Values{}
+	if err := mergeQuery(defaults, options); err != nil {
+		return nil, err
+	}
+	for key, values := range defaults {
+		if _, ok := q[key]; !ok {
+			q[key] = values
 		}
 	}
+
+	path := u.Path
 	if len(q) > 0 {
 		path = path + "?" + q.Encode()
 	}
 
-	// Make request
+	return c.getPath(requestID, path, result, options)
+}
+
+// nextPagePath returns the path of a NextPage, or the path of its URI
+// relative to the client's base URL if it has no path. An empty string is
+// returned if neither is usable, in which case the offset must be used.
+func (c *Client) nextPagePath(nextPage *NextPage) string {
+	if nextPage.Path != "" {
+		return nextPage.Path
+	}
+
+	base := strings.TrimSuffix(c.BaseURL.String(), "/")
+	if path := strings.TrimPrefix(nextPage.URI, base); path != nextPage.URI && strings.HasPrefix(path, "/") {
+		return path
+	}
+	return ""
+}
+
+// getPath makes a GET request for a path which already includes its query
+func (c *Client) getPath(requestID xid.ID, path string, result interface{}, options *Options) (*NextPage, error) {
 	if IsTrue(options.Debug) {
 		log.Printf("%s GET %s", requestID, path)
 	}
@@ -158,7 +332,9 @@ func (c *Client) get(path string, data, result interface{}, opts ...*Options) (*
 		return nil, errors.Wrapf(err, "%s Request error", requestID)
 	}
 	c.addHeaders(request, options)
-	resp, err := c.HTTPClient.Do(request)
+	request, cancel := withTimeout(request, options.Timeout)
+	defer cancel()
+	resp, err := c.send(request)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s GET error", requestID)
 	}
@@ -173,6 +349,12 @@ func (c *Client) get(path string, data, result interface{}, opts ...*Options) (*
 }
 
 func (c *Client) addHeaders(request *http.Request, options *Options) {
+	userAgent := c.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	request.Header.Set("User-Agent", userAgent)
+
 	if len(options.Enable) > 0 {
 		request.Header.Add("Asana-Enable", joinFeatures(options.Enable))
 	}
@@ -213,10 +395,7 @@ func (c *Client) do(method, path string, data, result interface{}, opts ...*Opti
 	requestID := xid.New()
 
 	// Prepare options
-	options, err := c.mergeOptions(opts...)
-	if err != nil {
-		return errors.Wrapf(err, "%s unable to merge options", requestID)
-	}
+	options := c.mergeOptions(opts...)
 
 	// Validate data
 	if validator, ok := data.(Validator); ok {
@@ -224,6 +403,9 @@ func (c *Client) do(method, path string, data, result interface{}, opts ...*Opti
 			return err
 		}
 	}
+	if err := checkFields(result, options); err != nil {
+		return err
+	}
 
 	// Build request
 	req := &request{
@@ -249,7 +431,9 @@ func (c *Client) do(method, path string, data, result interface{}, opts ...*Opti
 
 	request.Header.Add("Content-Type", "application/json")
 	c.addHeaders(request, options)
-	resp, err := c.HTTPClient.Do(request)
+	request, cancel := withTimeout(request, options.Timeout)
+	defer cancel()
+	resp, err := c.send(request)
 	if err != nil {
 		return errors.Wrapf(err, "%s error", method)
 	}
@@ -258,16 +442,20 @@ func (c *Client) do(method, path string, data, result interface{}, opts ...*Opti
 	return err
 }
 
-func (c *Client) mergeOptions(opts ...*Options) (*Options, error) {
-	var options *Options
-	if opts != nil {
-		options = opts[0]
-	}
-	if options == nil {
-		options = &Options{}
+// withTimeout applies the Timeout option to a request
+func withTimeout(request *http.Request, timeout time.Duration) (*http.Request, context.CancelFunc) {
+	if timeout <= 0 {
+		return request, func() {}
 	}
-	err := mergo.Merge(options, c.DefaultOptions)
-	return options, err
+
+	ctx, cancel := context.WithTimeout(request.Context(), timeout)
+	return request.WithContext(ctx), cancel
+}
+
+// mergeOptions combines the client's DefaultOptions with the options given to
+// a request, see MergeOptions
+func (c *Client) mergeOptions(opts ...*Options) *Options {
+	return MergeOptions(append([]*Options{&c.DefaultOptions}, opts...)...)
 }
 
 // From mime.multipart package ------
@@ -282,10 +470,7 @@ func escapeQuotes(s string) string {
 func (c *Client) postMultipart(path string, result interface{}, field string, r io.ReadCloser, filename string, contentType string, opts ...*Options) error {
 	// Make request
 	requestID := xid.New()
-	options, err := c.mergeOptions(opts...)
-	if err != nil {
-		return errors.Wrapf(err, "%s unable to merge options", requestID)
-	}
+	options := c.mergeOptions(opts...)
 
 	if IsTrue(options.Debug) {
 		log.Printf("%s POST multipart %s\n%s=%s;ContentType=%s", requestID, path, field, filename, contentType)
@@ -301,7 +486,7 @@ func (c *Client) postMultipart(path string, result interface{}, field string, r
 			escapeQuotes(field), escapeQuotes(filename)))
 	h.Set("Content-Type", contentType)
 
-	_, err = partWriter.CreatePart(h)
+	_, err := partWriter.CreatePart(h)
 	if err != nil {
 		return errors.Wrapf(err, "%s create multipart header", requestID)
 	}
@@ -323,7 +508,9 @@ func (c *Client) postMultipart(path string, result interface{}, field string, r
 
 	request.Header.Add("Content-Type", partWriter.FormDataContentType())
 	c.addHeaders(request, options)
-	resp, err := c.HTTPClient.Do(request)
+	request, cancel := withTimeout(request, options.Timeout)
+	defer cancel()
+	resp, err := c.send(request)
 	if err != nil {
 		return errors.Wrapf(err, "%s POST error", requestID)
 	}
@@ -334,6 +521,8 @@ func (c *Client) postMultipart(path string, result interface{}, field string, r
 
 func (c *Client) parseResponse(resp *http.Response, result interface{}, requestID xid.ID, options *Options) (*Response, error) {
 
+	c.recordDeprecations(resp)
+
 	// Get response body
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
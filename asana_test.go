@@ -0,0 +1,41 @@
+package asana
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client which sends requests to the handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL)
+	return client
+}
+
+func TestOptions_Timeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`{"data": {"gid": "1"}}`))
+	})
+
+	task := &Task{ID: "1"}
+	start := time.Now()
+	err := task.Fetch(client, &Options{Timeout: 20 * time.Millisecond})
+	if err == nil {
+		t.Fatal("Expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the request to be cancelled after the timeout, but it took %v", elapsed)
+	}
+}
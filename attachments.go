@@ -1,9 +1,12 @@
 package asana
 
 import (
+	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"io"
+	"net/http"
+	"os"
 	"time"
 )
 
@@ -22,11 +25,12 @@ type Attachment struct {
 
 	// Read-only. The service hosting the attachment. Valid values are
 	// asana, dropbox, gdrive, onedrive, box, vimeo, and external.
-	ResourceSubtype string `json:"resource_subtype,omitempty"`
+	ResourceSubtype AttachmentHost `json:"resource_subtype,omitempty"`
 
 	// Read-only. Whether the attachment is connected to the app making the request for the
 	// purposes of showing an app components widget.
-	// Only present when the resource_subtype is external or gdrive.
+	// Only present when the resource_subtype is external or gdrive, so nil
+	// means the connection does not apply rather than false.
 	ConnectedToApp *bool `json:"connected_to_app,omitempty"`
 
 	// Read-only. The time at which this object was created.
@@ -34,20 +38,23 @@ type Attachment struct {
 
 	// Read-only. The URL containing the content of the attachment.
 	//
-	// Note: May be null if the attachment is hosted by box. If present, this
-	// URL may only be valid for 1 hour from the time of retrieval. You should
-	// avoid persisting this URL somewhere and just refresh it on demand to
-	// ensure you do not keep stale URLs.
+	// Note: May be null if the attachment is hosted by box, and for external
+	// links. If present, this URL may only be valid for 1 hour from the time
+	// of retrieval. You should avoid persisting this URL somewhere and just
+	// refresh it on demand to ensure you do not keep stale URLs.
 	DownloadURL string `json:"download_url,omitempty"`
 
 	// Read-only. The service hosting the attachment. Valid values are
-	// asana, dropbox, gdrive, box, and vimeo.
-	Host string `json:"host,omitempty"`
+	// asana, dropbox, gdrive, onedrive, box, vimeo, and external. This is
+	// the same as ResourceSubtype, which newer responses prefer.
+	Host AttachmentHost `json:"host,omitempty"`
 
 	// Read-only. The task this object is attached to.
 	Parent *Task `json:"parent,omitempty"`
 
-	// Undocumented. A permanent asana.com link which should be a permalink
+	// Read-only. A stable link to the attachment in the Asana web app. Unlike
+	// the download URL it does not expire, so it is the URL to store or
+	// share.
 	PermanentURL string `json:"permanent_url,omitempty"`
 
 	// Read-only. The size of the attachment in bytes. Only present when the resource_subtype is asana.
@@ -55,10 +62,30 @@ type Attachment struct {
 
 	// Read-only. The URL where the attachment can be viewed, which may be
 	// friendlier to users in a browser than just directing them to a raw
-	// file.
+	// file. For attachments hosted by other services this is the file on
+	// that service.
 	ViewURL string `json:"view_url,omitempty"`
 }
 
+// host returns the service hosting the attachment, from whichever of the
+// resource_subtype and host fields was loaded
+func (a *Attachment) host() AttachmentHost {
+	if a.ResourceSubtype != "" {
+		return a.ResourceSubtype
+	}
+	return a.Host
+}
+
+// IsExternal returns true if the attachment is hosted by a service other
+// than Asana, such as Google Drive or Dropbox, or is a link to an external
+// URL. The content of external attachments cannot be downloaded through the
+// API, so link to their ViewURL instead. An attachment loaded without its
+// resource_subtype or host is not considered external.
+func (a *Attachment) IsExternal() bool {
+	host := a.host()
+	return host != "" && host != AttachmentHostAsana
+}
+
 func (a *Attachment) GetID() string {
 	return a.ID
 }
@@ -74,6 +101,135 @@ func (t *Task) Attachments(client *Client, opts ...*Options) ([]*Attachment, *Ne
 	return result, nextPage, err
 }
 
+// Fetch loads the full details for this Attachment
+func (a *Attachment) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading attachment details for %q", a.Name)
+
+	_, err := client.get(fmt.Sprintf("/attachments/%s", a.ID), nil, a, opts...)
+	return err
+}
+
+// Download opens the content of this attachment for reading, see
+// DownloadContext
+func (a *Attachment) Download(client *Client) (io.ReadCloser, error) {
+	return a.DownloadContext(context.Background(), client)
+}
+
+// DownloadContext opens the content of this attachment for reading. The
+// download URL is loaded first if it is not already present. The caller must
+// close the returned reader. The download stops with an error once the
+// context is done.
+//
+// The download URL is pre-signed, so it is requested without the client's
+// credentials, see Client.DownloadClient. Download URLs expire an hour after
+// they are loaded: if the storage service rejects a URL which was loaded
+// earlier, the attachment is fetched again and the download retried once
+// with the new URL.
+func (a *Attachment) DownloadContext(ctx context.Context, client *Client) (io.ReadCloser, error) {
+	refresh := func() (string, error) {
+		if err := a.Fetch(client, &Options{Fields: []string{"name", "download_url", "size"}}); err != nil {
+			return "", err
+		}
+		if a.DownloadURL == "" {
+			return "", errors.Errorf("Attachment %s has no download URL", a.ID)
+		}
+		return a.DownloadURL, nil
+	}
+
+	downloadURL := a.DownloadURL
+	if downloadURL == "" {
+		var err error
+		if downloadURL, err = refresh(); err != nil {
+			return nil, err
+		}
+
+		// The URL is fresh, so there is no point refreshing it again
+		refresh = nil
+	}
+
+	client.trace("Downloading attachment %q", a.Name)
+	return client.downloadPresigned(ctx, downloadURL, "Download attachment", refresh)
+}
+
+// downloadClient returns the HTTP client for pre-signed downloads
+func (c *Client) downloadClient() *http.Client {
+	if c.DownloadClient != nil {
+		return c.DownloadClient
+	}
+	return defaultDownloadClient
+}
+
+// downloadPresigned requests a pre-signed download URL. These URLs carry
+// their own authorization, and the storage service rejects requests which
+// also include the client's credentials, so the DownloadClient is used
+// instead of the HTTPClient.
+//
+// If the URL is rejected with 403 Forbidden, as it is once it has expired,
+// and a refresh function is given, the download is retried once with the
+// URL it returns.
+func (c *Client) downloadPresigned(ctx context.Context, downloadURL, action string, refresh func() (string, error)) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, action)
+	}
+
+	resp, err := c.downloadClient().Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, action)
+	}
+	if resp.StatusCode == http.StatusForbidden && refresh != nil {
+		resp.Body.Close()
+
+		freshURL, err := refresh()
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: refresh expired URL", action)
+		}
+		return c.downloadPresigned(ctx, freshURL, action, nil)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("%s: unexpected status %s", action, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// SaveTo downloads the content of this attachment to a file at the given
+// path, see SaveToContext
+func (a *Attachment) SaveTo(client *Client, path string) (int64, error) {
+	return a.SaveToContext(context.Background(), client, path)
+}
+
+// SaveToContext downloads the content of this attachment to a file at the
+// given path and returns the number of bytes written. If the attachment's
+// size is known, a download of any other length is treated as truncated and
+// returns an error. The file is removed if the download fails.
+func (a *Attachment) SaveToContext(ctx context.Context, client *Client, path string) (int64, error) {
+	body, err := a.DownloadContext(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, errors.Wrap(err, "Create attachment file")
+	}
+
+	n, err := io.Copy(f, body)
+	if err == nil && a.Size != nil && n != int64(*a.Size) {
+		err = errors.Errorf("Attachment %s download was %d bytes, expected %d", a.ID, n, *a.Size)
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = errors.Wrap(closeErr, "Write attachment file")
+	}
+
+	if err != nil {
+		os.Remove(path)
+		return 0, errors.Wrap(err, "Save attachment")
+	}
+	return n, nil
+}
+
 type NewAttachment struct {
 	Reader      io.ReadCloser
 	FileName    string
@@ -92,15 +248,15 @@ func (t *Task) CreateAttachment(client *Client, request *NewAttachment) (*Attach
 }
 
 type ExternalAttachmentRequest struct {
-	ConnectToApp    *bool  `json:"connect_to_app,omitempty"`
-	Name            string `json:"name"`
-	URL             string `json:"url"`
-	ResourceSubtype string `json:"resource_subtype"`
+	ConnectToApp    *bool          `json:"connect_to_app,omitempty"`
+	Name            string         `json:"name"`
+	URL             string         `json:"url"`
+	ResourceSubtype AttachmentHost `json:"resource_subtype"`
 }
 
 func (t *Task) CreateExternalAttachment(client *Client, request *ExternalAttachmentRequest) (*Attachment, error) {
 	client.trace("Creating external attachment for %q", t.Name)
-	request.ResourceSubtype = "external"
+	request.ResourceSubtype = AttachmentHostExternal
 
 	result := &Attachment{}
 	err := client.post(fmt.Sprintf("/tasks/%s/attachments", t.ID), request, result)
@@ -109,3 +265,16 @@ func (t *Task) CreateExternalAttachment(client *Client, request *ExternalAttachm
 	}
 	return result, nil
 }
+
+// GID returns the globally unique ID of this Attachment
+func (a *Attachment) GID() string {
+	return a.ID
+}
+
+// Type returns the resource type of this Attachment
+func (a *Attachment) Type() string {
+	if a.ResourceType != "" {
+		return a.ResourceType
+	}
+	return "attachment"
+}
@@ -0,0 +1,88 @@
+package asana
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAttachment_Download_RefreshesExpiredURL(t *testing.T) {
+	var serverURL string
+	fetches := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/attachments/1":
+			fetches++
+			w.Write([]byte(`{"data": {"gid": "1", "download_url": "` + serverURL + `/files/fresh"}}`))
+		case "/files/expired":
+			w.WriteHeader(http.StatusForbidden)
+		case "/files/fresh":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("Expected the download not to send credentials")
+			}
+			w.Write([]byte("content"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	serverURL = client.BaseURL.String()
+
+	attachment := &Attachment{ID: "1", DownloadURL: serverURL + "/files/expired"}
+	body, err := attachment.Download(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	content, _ := io.ReadAll(body)
+	if string(content) != "content" {
+		t.Errorf("Expected the content from the fresh URL, but saw %q", content)
+	}
+	if fetches != 1 || attachment.DownloadURL != serverURL+"/files/fresh" {
+		t.Errorf("Expected the attachment to be fetched once for a new URL, but saw %d fetches and %s", fetches, attachment.DownloadURL)
+	}
+}
+
+func TestAttachment_DownloadContext_Cancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("content"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attachment := &Attachment{ID: "1", DownloadURL: client.BaseURL.String() + "/files/1"}
+	if body, err := attachment.DownloadContext(ctx, client); err == nil {
+		body.Close()
+		t.Error("Expected the download to fail once the context is cancelled")
+	}
+}
+
+func TestAttachment_IsExternal(t *testing.T) {
+	fixtures := []struct {
+		json     string
+		external bool
+	}{
+		{`{"gid": "1", "resource_subtype": "asana", "host": "asana", "download_url": "https://s3.amazonaws.com/file", "size": 12}`, false},
+		{`{"gid": "2", "resource_subtype": "dropbox", "host": "dropbox", "view_url": "https://www.dropbox.com/s/file"}`, true},
+		{`{"gid": "3", "resource_subtype": "gdrive", "host": "gdrive", "connected_to_app": false, "view_url": "https://drive.google.com/file"}`, true},
+		{`{"gid": "4", "resource_subtype": "onedrive", "host": "onedrive", "view_url": "https://onedrive.live.com/file"}`, true},
+		{`{"gid": "5", "resource_subtype": "box", "host": "box", "view_url": "https://app.box.com/file"}`, true},
+		{`{"gid": "6", "resource_subtype": "vimeo", "host": "vimeo", "view_url": "https://vimeo.com/1"}`, true},
+		{`{"gid": "7", "resource_subtype": "external", "host": "external", "connected_to_app": true, "view_url": "https://example.com/"}`, true},
+		{`{"gid": "8", "host": "dropbox"}`, true},
+		{`{"gid": "9"}`, false},
+	}
+
+	for _, fixture := range fixtures {
+		attachment := &Attachment{}
+		if err := json.Unmarshal([]byte(fixture.json), attachment); err != nil {
+			t.Fatal(err)
+		}
+		if attachment.IsExternal() != fixture.external {
+			t.Errorf("Expected IsExternal to be %v for %s", fixture.external, fixture.json)
+		}
+	}
+}
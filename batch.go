@@ -0,0 +1,171 @@
+package asana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// maxBatchActions is the largest number of actions the API accepts in a
+// single batch request
+const maxBatchActions = 10
+
+// BatchActionOptions are the options of a single action in a batch request
+type BatchActionOptions struct {
+	Fields []string `json:"fields,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+	Offset string   `json:"offset,omitempty"`
+}
+
+// BatchAction is a single request within a batch request
+type BatchAction struct {
+	// The path of the request relative to the API base URL, e.g. /tasks/123
+	RelativePath string `json:"relative_path"`
+
+	// The HTTP method of the request: get, post, put or delete
+	Method string `json:"method"`
+
+	// The data of a post or put request
+	Data interface{} `json:"data,omitempty"`
+
+	Options *BatchActionOptions `json:"options,omitempty"`
+}
+
+// BatchResult is the response to a single action of a batch request
+type BatchResult struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body"`
+}
+
+// Decode parses the data of a successful action into result, or returns the
+// error of a failed action
+func (r *BatchResult) Decode(result interface{}) error {
+	value := &Response{}
+	if err := json.Unmarshal(r.Body, value); err != nil {
+		return errors.Wrap(err, "Unable to parse batch action response")
+	}
+
+	if r.StatusCode/100 != 2 {
+		if len(value.Errors) > 0 {
+			return value.Errors[0].withType(r.StatusCode, http.StatusText(r.StatusCode))
+		}
+		return &Error{
+			StatusCode: r.StatusCode,
+			Type:       http.StatusText(r.StatusCode),
+			Message:    "Unknown error",
+		}
+	}
+
+	if result == nil || value.Data == nil {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(value.Data, result), "Unable to parse batch action data")
+}
+
+// Batch makes up to 10 requests in a single round trip. The results are
+// returned in the same order as the actions; the failure of one action does
+// not affect the others, so check each result with BatchResult.Decode.
+func (c *Client) Batch(actions []*BatchAction) ([]*BatchResult, error) {
+	c.trace("Making batch request of %d actions", len(actions))
+
+	if len(actions) > maxBatchActions {
+		return nil, errors.Errorf("A batch request can have at most %d actions, not %d", maxBatchActions, len(actions))
+	}
+
+	m := map[string]interface{}{
+		"actions": actions,
+	}
+
+	var result []*BatchResult
+	err := c.post("/batch", m, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Batch request")
+	}
+	if len(result) != len(actions) {
+		return nil, errors.Errorf("Batch request returned %d results for %d actions", len(result), len(actions))
+	}
+	return result, nil
+}
+
+// collectionPaths maps resource types to the collection they are loaded from
+var collectionPaths = map[string]string{
+	"attachment":       "attachments",
+	"custom_field":     "custom_fields",
+	"goal":             "goals",
+	"portfolio":        "portfolios",
+	"project":          "projects",
+	"project_brief":    "project_briefs",
+	"project_template": "project_templates",
+	"section":          "sections",
+	"story":            "stories",
+	"tag":              "tags",
+	"task":             "tasks",
+	"team":             "teams",
+	"time_period":      "time_periods",
+	"user":             "users",
+	"webhook":          "webhooks",
+	"workspace":        "workspaces",
+}
+
+// ExpandAll loads the full records of many compact resources, such as the
+// assignees of a list of tasks, using batch requests of 10 resources at a
+// time. Resources are loaded based on their GID and type, whether or not
+// they have been expanded already. Each resource is populated in place.
+// Resources with the same type and GID are loaded once, and all of them are
+// populated.
+//
+// A resource which cannot be loaded does not stop the others from being
+// loaded: the errors are returned together as a MultiError.
+func ExpandAll(client *Client, resources []Resource) error {
+	errs := &MultiError{}
+
+	// Group resources by path, keeping the order they were first seen in
+	var paths []string
+	byPath := make(map[string][]Resource)
+	for _, resource := range resources {
+		collection, ok := collectionPaths[resource.Type()]
+		if !ok {
+			errs.Errors = append(errs.Errors, errors.Errorf("Cannot expand %s %s", resource.Type(), resource.GID()))
+			continue
+		}
+
+		path := fmt.Sprintf("/%s/%s", collection, resource.GID())
+		if _, seen := byPath[path]; !seen {
+			paths = append(paths, path)
+		}
+		byPath[path] = append(byPath[path], resource)
+	}
+
+	for start := 0; start < len(paths); start += maxBatchActions {
+		end := start + maxBatchActions
+		if end > len(paths) {
+			end = len(paths)
+		}
+		chunk := paths[start:end]
+
+		actions := make([]*BatchAction, len(chunk))
+		for i, path := range chunk {
+			actions[i] = &BatchAction{RelativePath: path, Method: "get"}
+		}
+
+		results, err := client.Batch(actions)
+		if err != nil {
+			errs.Errors = append(errs.Errors, err)
+			continue
+		}
+
+		for i, path := range chunk {
+			for _, resource := range byPath[path] {
+				if err := results[i].Decode(resource); err != nil {
+					errs.Errors = append(errs.Errors, errors.Wrapf(err, "Expand %s", path))
+					break
+				}
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
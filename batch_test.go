@@ -0,0 +1,70 @@
+package asana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestExpandAll(t *testing.T) {
+	var batches [][]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data struct {
+				Actions []*BatchAction `json:"actions"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		var paths []string
+		var results []*BatchResult
+		for _, action := range body.Data.Actions {
+			paths = append(paths, action.RelativePath)
+			if action.RelativePath == "/users/404" {
+				results = append(results, &BatchResult{
+					StatusCode: 404,
+					Body:       json.RawMessage(`{"errors": [{"message": "Not found"}]}`),
+				})
+				continue
+			}
+			results = append(results, &BatchResult{
+				StatusCode: 200,
+				Body:       json.RawMessage(fmt.Sprintf(`{"data": {"gid": "%s", "name": "Loaded"}}`, action.RelativePath[len("/users/"):])),
+			})
+		}
+		batches = append(batches, paths)
+
+		data, _ := json.Marshal(map[string]interface{}{"data": results})
+		w.Write(data)
+	})
+
+	var resources []Resource
+	var users []*User
+	for i := 0; i < 12; i++ {
+		user := &User{ID: fmt.Sprint(i)}
+		users = append(users, user)
+		resources = append(resources, user)
+	}
+	duplicate := &User{ID: "0"}
+	missing := &User{ID: "404"}
+	resources = append(resources, duplicate, missing)
+
+	err := ExpandAll(client, resources)
+	if err == nil {
+		t.Error("Expected an error for the missing user")
+	} else if multi, ok := err.(*MultiError); !ok || len(multi.Errors) != 1 {
+		t.Errorf("Expected a single error for the missing user, but saw %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 10 || len(batches[1]) != 3 {
+		t.Errorf("Expected batches of 10 and 3 distinct users, but saw %v", batches)
+	}
+	for _, user := range append(users, duplicate) {
+		if user.Name != "Loaded" {
+			t.Errorf("Expected user %s to be expanded", user.ID)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package asana
+
+import (
+	"sync"
+)
+
+// maxBoardRequests is the number of sections whose tasks Board lists at the
+// same time
+const maxBoardRequests = 4
+
+// ProjectBoard is the layout of a project as sections of ordered tasks, mirroring
+// the columns of its board view
+type ProjectBoard struct {
+	Project *Project
+	Columns []*BoardColumn
+}
+
+// BoardColumn is a section of a project and its tasks, in the order they
+// appear in the project
+type BoardColumn struct {
+	Section *Section
+
+	// The tasks in the section, which is empty rather than nil for a section
+	// without tasks
+	Tasks []*Task
+}
+
+// Board loads the sections of this project in order, with the tasks of each
+// section in order. The tasks of several sections are listed at the same
+// time. Options are applied to the task listings, e.g. to request the fields
+// shown on each card.
+func (p *Project) Board(client *Client, opts ...*Options) (*ProjectBoard, error) {
+	client.trace("Loading board of project %q", p.Name)
+
+	sections, err := listAll(client, 100, func(opts ...*Options) ([]*Section, *NextPage, error) {
+		return p.Sections(client, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	board := &ProjectBoard{
+		Project: p,
+		Columns: make([]*BoardColumn, len(sections)),
+	}
+
+	errs := make([]error, len(sections))
+	requests := make(chan struct{}, maxBoardRequests)
+	wait := sync.WaitGroup{}
+
+	for i, section := range sections {
+		wait.Add(1)
+		go func(i int, section *Section) {
+			defer wait.Done()
+			requests <- struct{}{}
+			defer func() { <-requests }()
+
+			tasks, err := listAll(client, 100, func(opts ...*Options) ([]*Task, *NextPage, error) {
+				return section.Tasks(client, opts...)
+			}, opts...)
+			if tasks == nil {
+				tasks = []*Task{}
+			}
+
+			board.Columns[i] = &BoardColumn{Section: section, Tasks: tasks}
+			errs[i] = err
+		}(i, section)
+	}
+	wait.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return board, nil
+}
@@ -0,0 +1,52 @@
+package asana
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestProject_Board(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/1/sections":
+			w.Write([]byte(`{"data": [{"gid": "10", "name": "To do"}, {"gid": "11", "name": "Empty"}, {"gid": "12", "name": "Done"}]}`))
+		case "/sections/10/tasks":
+			w.Write([]byte(`{"data": [{"gid": "100"}, {"gid": "101"}]}`))
+		case "/sections/11/tasks":
+			w.Write([]byte(`{"data": []}`))
+		case "/sections/12/tasks":
+			w.Write([]byte(`{"data": [{"gid": "102"}]}`))
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+	})
+
+	board, err := (&Project{ID: "1"}).Board(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		section string
+		tasks   []string
+	}{
+		{"10", []string{"100", "101"}},
+		{"11", nil},
+		{"12", []string{"102"}},
+	}
+	if len(board.Columns) != len(expected) {
+		t.Fatalf("Expected %d columns, but saw %d", len(expected), len(board.Columns))
+	}
+	for i, e := range expected {
+		column := board.Columns[i]
+		if column.Section.ID != e.section || column.Tasks == nil || len(column.Tasks) != len(e.tasks) {
+			t.Errorf("Unexpected column %d: %+v", i, column)
+			continue
+		}
+		for j, id := range e.tasks {
+			if column.Tasks[j].ID != id {
+				t.Errorf("Expected task %s at position %d of section %s, but saw %s", id, j, e.section, column.Tasks[j].ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package asana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ProjectBrief is a rich text document which describes the purpose and
+// details of a project. A project has at most one brief.
+type ProjectBrief struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// The title of the project brief.
+	Title string `json:"title,omitempty"`
+
+	// HTML formatted text for the project brief.
+	HTMLText string `json:"html_text,omitempty"`
+
+	// Read-only. The plain text of the project brief.
+	Text string `json:"text,omitempty"`
+
+	// Read-only. A url that points directly to the object within Asana.
+	PermalinkURL string `json:"permalink_url,omitempty"`
+
+	// Read-only. The project with which this project brief is associated.
+	Project *Project `json:"project,omitempty"`
+}
+
+// ProjectBriefRequest is the data used to create or update a project brief
+type ProjectBriefRequest struct {
+	Title    string `json:"title,omitempty"`
+	HTMLText string `json:"html_text,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// Validate sanitizes the rich text of the brief, removing tags and
+// attributes which the API would reject
+func (r *ProjectBriefRequest) Validate() error {
+	if r.HTMLText == "" {
+		return nil
+	}
+
+	text, err := SanitizeHTMLText(r.HTMLText)
+	if err != nil {
+		return err
+	}
+	r.HTMLText = text
+	return nil
+}
+
+// Fetch loads the full details for this ProjectBrief
+func (b *ProjectBrief) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading project brief details for %q", b.Title)
+
+	_, err := client.get(fmt.Sprintf("/project_briefs/%s", b.ID), nil, b, opts...)
+	return err
+}
+
+// Update applies new values to the project brief
+func (b *ProjectBrief) Update(client *Client, request *ProjectBriefRequest, opts ...*Options) error {
+	client.trace("Updating project brief %q", b.Title)
+
+	err := client.put(fmt.Sprintf("/project_briefs/%s", b.ID), request, b, opts...)
+	return errors.Wrap(err, "Update project brief")
+}
+
+// CreateBrief adds a project brief to this project
+func (p *Project) CreateBrief(client *Client, request *ProjectBriefRequest) (*ProjectBrief, error) {
+	client.trace("Creating project brief for %q", p.Name)
+
+	result := &ProjectBrief{}
+	err := client.post(fmt.Sprintf("/projects/%s/project_briefs", p.ID), request, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Create project brief")
+	}
+	return result, nil
+}
+
+// GID returns the globally unique ID of this ProjectBrief
+func (b *ProjectBrief) GID() string {
+	return b.ID
+}
+
+// Type returns the resource type of this ProjectBrief
+func (b *ProjectBrief) Type() string {
+	if b.ResourceType != "" {
+		return b.ResourceType
+	}
+	return "project_brief"
+}
@@ -0,0 +1,55 @@
+package asana
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxIDsPerRequest is the number of IDs sent in a single request to the
+	// add and remove endpoints which accept a list of objects, such as
+	// addFollowers and addMembers. Larger lists are split across several
+	// requests. The API does not document a maximum for these endpoints, but
+	// long lists are slow to apply and may time out; 20 keeps each request
+	// small while needing few requests for typical lists.
+	maxIDsPerRequest = 20
+
+	// maxDependencies and maxDependents are the documented limits on the
+	// number of dependencies and dependents of a task. These are limits on
+	// the total, so longer lists are rejected rather than split.
+	maxDependencies = 15
+	maxDependents   = 30
+)
+
+// chunkIDs splits a list of IDs into lists of at most size IDs
+func chunkIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
+
+// postChunked posts a list of IDs to an endpoint in chunks of at most size
+// IDs, sent as the given field of the request data. Every chunk is sent even
+// if an earlier one fails, and the errors are returned as a MultiError. An
+// empty list is an error, as it is for the API.
+func (c *Client) postChunked(path, field string, ids []string, size int, result interface{}) error {
+	if len(ids) == 0 {
+		return errors.Errorf("No %s given", field)
+	}
+
+	errs := &MultiError{}
+	for _, chunk := range chunkIDs(ids, size) {
+		m := map[string]interface{}{
+			field: chunk,
+		}
+		if err := c.post(path, m, result); err != nil {
+			errs.Errors = append(errs.Errors, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,47 @@
+package asana
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestChunkIDs(t *testing.T) {
+	tests := []struct {
+		ids      []string
+		expected [][]string
+	}{
+		{nil, nil},
+		{[]string{"1", "2"}, [][]string{{"1", "2"}}},
+		{[]string{"1", "2", "3"}, [][]string{{"1", "2", "3"}}},
+		{[]string{"1", "2", "3", "4", "5", "6", "7"}, [][]string{{"1", "2", "3"}, {"4", "5", "6"}, {"7"}}},
+	}
+
+	for _, test := range tests {
+		if chunks := chunkIDs(test.ids, 3); !reflect.DeepEqual(chunks, test.expected) {
+			t.Errorf("Expected %v to be split into %v, but saw %v", test.ids, test.expected, chunks)
+		}
+	}
+}
+
+func TestAddDependenciesRequest_Validate(t *testing.T) {
+	if err := (&AddDependenciesRequest{}).Validate(); err == nil {
+		t.Error("Expected an empty list of dependencies to be invalid")
+	}
+	if err := (&AddDependenciesRequest{Dependencies: make([]string, 16)}).Validate(); err == nil {
+		t.Error("Expected more than 15 dependencies to be invalid")
+	}
+	if err := (&AddDependentsRequest{Dependents: make([]string, 30)}).Validate(); err != nil {
+		t.Errorf("Expected 30 dependents to be valid, but saw %v", err)
+	}
+}
+
+func TestPostChunked_RejectsEmptyList(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request for an empty list, but saw %s", r.URL)
+	})
+
+	if err := (&Task{ID: "1"}).AddFollowers(client, nil); err == nil {
+		t.Error("Expected an error for an empty list of followers")
+	}
+}
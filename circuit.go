@@ -0,0 +1,138 @@
+package asana
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultCircuitCooldown is how long requests are rejected after the circuit
+// breaker trips, if Client.CircuitCooldown is not set
+const defaultCircuitCooldown = 30 * time.Second
+
+// CircuitOpenError is returned without making a request while the client's
+// circuit breaker is open, see Client.CircuitThreshold
+type CircuitOpenError struct {
+	// The time after which a request will be allowed through to probe
+	// whether the API has recovered
+	RetryAt time.Time
+}
+
+func (err *CircuitOpenError) Error() string {
+	return fmt.Sprintf("Circuit breaker open after repeated API failures, retry after %s", err.RetryAt.Format(time.RFC3339))
+}
+
+// IsCircuitOpen returns true if the error was caused by the client's circuit
+// breaker rejecting a request
+func IsCircuitOpen(err error) bool {
+	var e *CircuitOpenError
+	return errors.As(err, &e)
+}
+
+// circuit tracks consecutive failures for the client's circuit breaker
+type circuit struct {
+	lock     sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// send makes an HTTP request through the circuit breaker and the rate
+// limiter, see Client.RateLimit. When the breaker is open, requests fail
+// with a CircuitOpenError until the cooldown has passed, without spending
+// any of the rate limit budget. A single request is then let through as a
+// probe: if it succeeds the breaker closes, otherwise it opens for another
+// cooldown.
+func (c *Client) send(request *http.Request) (*http.Response, error) {
+	if err := c.allowRequest(); err != nil {
+		return nil, err
+	}
+	if err := c.waitForBudget(request); err != nil {
+		c.releaseProbe()
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(request)
+	if err != nil && request.Context().Err() != nil {
+		// Cancelled by the caller, e.g. through Options.Timeout, which says
+		// nothing about the health of the API
+		c.releaseProbe()
+		return resp, err
+	}
+	c.recordResult(resp, err)
+	return resp, err
+}
+
+// releaseProbe lets another request probe the API if the request which was
+// let through as the probe did not complete
+func (c *Client) releaseProbe() {
+	if c.CircuitThreshold <= 0 {
+		return
+	}
+
+	c.circuit.lock.Lock()
+	defer c.circuit.lock.Unlock()
+	c.circuit.probing = false
+}
+
+func (c *Client) allowRequest() error {
+	if c.CircuitThreshold <= 0 {
+		return nil
+	}
+
+	c.circuit.lock.Lock()
+	defer c.circuit.lock.Unlock()
+
+	if c.circuit.failures < c.CircuitThreshold {
+		return nil
+	}
+
+	retryAt := c.circuit.openedAt.Add(c.circuitCooldown())
+	if c.circuit.probing || time.Now().Before(retryAt) {
+		return &CircuitOpenError{RetryAt: retryAt}
+	}
+
+	// Half open: let this request through to probe the API
+	c.circuit.probing = true
+	return nil
+}
+
+// recordResult counts rate limit and server errors, and transport errors,
+// as failures. Requests cancelled through their own context are not
+// recorded, see send.
+func (c *Client) recordResult(resp *http.Response, err error) {
+	if c.CircuitThreshold <= 0 {
+		return
+	}
+
+	failed := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+
+	c.circuit.lock.Lock()
+	defer c.circuit.lock.Unlock()
+
+	if !failed {
+		c.circuit.failures = 0
+		c.circuit.probing = false
+		return
+	}
+
+	c.circuit.failures++
+	if c.circuit.probing || c.circuit.failures == c.CircuitThreshold {
+		c.info("Circuit breaker open after %d consecutive failures", c.circuit.failures)
+		c.circuit.openedAt = time.Now()
+	}
+	if c.circuit.failures > c.CircuitThreshold {
+		c.circuit.failures = c.CircuitThreshold
+	}
+	c.circuit.probing = false
+}
+
+func (c *Client) circuitCooldown() time.Duration {
+	if c.CircuitCooldown > 0 {
+		return c.CircuitCooldown
+	}
+	return defaultCircuitCooldown
+}
@@ -0,0 +1,78 @@
+package asana
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	client := &Client{CircuitThreshold: 2, CircuitCooldown: 20 * time.Millisecond}
+	serverError := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	client.recordResult(serverError, nil)
+	if err := client.allowRequest(); err != nil {
+		t.Fatalf("Expected the breaker to be closed after one failure, but saw %v", err)
+	}
+
+	client.recordResult(&http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+	if err := client.allowRequest(); !IsCircuitOpen(err) {
+		t.Fatalf("Expected the breaker to open, but saw %v", err)
+	}
+
+	// After the cooldown a single probe is allowed
+	time.Sleep(25 * time.Millisecond)
+	if err := client.allowRequest(); err != nil {
+		t.Fatalf("Expected a probe after the cooldown, but saw %v", err)
+	}
+	if err := client.allowRequest(); !IsCircuitOpen(err) {
+		t.Fatalf("Expected other requests to wait for the probe, but saw %v", err)
+	}
+
+	// A failed probe opens the breaker again
+	client.recordResult(serverError, nil)
+	if err := client.allowRequest(); !IsCircuitOpen(err) {
+		t.Fatalf("Expected the breaker to reopen after a failed probe, but saw %v", err)
+	}
+
+	// A successful probe closes it
+	time.Sleep(25 * time.Millisecond)
+	if err := client.allowRequest(); err != nil {
+		t.Fatalf("Expected a probe after the cooldown, but saw %v", err)
+	}
+	client.recordResult(ok, nil)
+	if err := client.allowRequest(); err != nil {
+		t.Errorf("Expected the breaker to close after a successful probe, but saw %v", err)
+	}
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	client := &Client{}
+	for i := 0; i < 10; i++ {
+		client.recordResult(&http.Response{StatusCode: http.StatusInternalServerError}, nil)
+	}
+	if err := client.allowRequest(); err != nil {
+		t.Errorf("Expected no breaker by default, but saw %v", err)
+	}
+}
+
+func TestCircuitBreaker_IgnoresCancelledRequests(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	client.CircuitThreshold = 2
+
+	task := &Task{ID: "1"}
+	for i := 0; i < 3; i++ {
+		if err := task.Fetch(client, &Options{Timeout: 5 * time.Millisecond}); err == nil {
+			t.Fatal("Expected the request to time out")
+		}
+	}
+	if err := client.allowRequest(); err != nil {
+		t.Errorf("Expected timeouts set by the caller not to open the breaker, but saw %v", err)
+	}
+}
@@ -123,7 +123,11 @@ func listStories(task *asana.Task, client *asana.Client) {
 	enc.SetIndent("", "  ")
 
 	for _, s := range stories {
-		fmt.Printf("Story %s (%s):\n", s.ID, s.CreatedBy.Name)
+		author := "system"
+		if !s.IsSystemGenerated() {
+			author = s.CreatedBy.Name
+		}
+		fmt.Printf("Story %s (%s):\n", s.ID, author)
 		check(enc.Encode(s))
 	}
 }
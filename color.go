@@ -0,0 +1,88 @@
+package asana
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Color is one of the fixed palette of colors which can be applied to
+// projects and tags. Custom field enum options use a different set of
+// color names, such as "red" or "blue-green", which are not validated.
+type Color string
+
+// Colors accepted by the API
+const (
+	ColorDarkPink     Color = "dark-pink"
+	ColorDarkGreen    Color = "dark-green"
+	ColorDarkBlue     Color = "dark-blue"
+	ColorDarkRed      Color = "dark-red"
+	ColorDarkTeal     Color = "dark-teal"
+	ColorDarkBrown    Color = "dark-brown"
+	ColorDarkOrange   Color = "dark-orange"
+	ColorDarkPurple   Color = "dark-purple"
+	ColorDarkWarmGray Color = "dark-warm-gray"
+
+	ColorLightPink     Color = "light-pink"
+	ColorLightGreen    Color = "light-green"
+	ColorLightBlue     Color = "light-blue"
+	ColorLightRed      Color = "light-red"
+	ColorLightTeal     Color = "light-teal"
+	ColorLightBrown    Color = "light-brown"
+	ColorLightYellow   Color = "light-yellow"
+	ColorLightOrange   Color = "light-orange"
+	ColorLightPurple   Color = "light-purple"
+	ColorLightWarmGray Color = "light-warm-gray"
+
+	// ColorNone removes the color from an object
+	ColorNone Color = "none"
+)
+
+var validColors = map[Color]bool{
+	ColorDarkPink: true, ColorDarkGreen: true, ColorDarkBlue: true, ColorDarkRed: true, ColorDarkTeal: true,
+	ColorDarkBrown: true, ColorDarkOrange: true, ColorDarkPurple: true, ColorDarkWarmGray: true,
+	ColorLightPink: true, ColorLightGreen: true, ColorLightBlue: true, ColorLightRed: true, ColorLightTeal: true,
+	ColorLightBrown: true, ColorLightYellow: true, ColorLightOrange: true, ColorLightPurple: true, ColorLightWarmGray: true,
+	ColorNone: true,
+}
+
+func (c Color) String() string {
+	return string(c)
+}
+
+// IsValid returns true if the color is part of the palette accepted by the
+// API. The empty color is valid and leaves the color unchanged.
+func (c Color) IsValid() bool {
+	return c == "" || validColors[c]
+}
+
+// Validate returns an error if the color is not accepted by the API
+func (c Color) Validate() error {
+	if !c.IsValid() {
+		return errors.Errorf("Invalid color %q", string(c))
+	}
+	return nil
+}
+
+// Validate checks the project color and notes before they are sent to the
+// API, and drops read-only fields
+func (p *CreateProjectRequest) Validate() error {
+	p.ProjectBase.clearReadOnly()
+	if err := Color(p.Color).Validate(); err != nil {
+		return err
+	}
+	return p.ProjectBase.validateNotes()
+}
+
+// Validate checks the project color and notes before they are sent to the
+// API, and drops read-only fields
+func (p *UpdateProjectRequest) Validate() error {
+	p.ProjectBase.clearReadOnly()
+	if err := Color(p.Color).Validate(); err != nil {
+		return err
+	}
+	return p.ProjectBase.validateNotes()
+}
+
+// Validate checks the tag color before it is sent to the API
+func (t *TagBase) Validate() error {
+	return Color(t.Color).Validate()
+}
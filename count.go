@@ -0,0 +1,29 @@
+package asana
+
+// Count returns the number of results of a paginated listing, such as
+// Project.Tasks, by paging through all of the results requesting only their
+// IDs. Pages after the first are loaded from the path in each NextPage.
+//
+// The API uses offset pagination and does not report the total number of
+// results, so counting is as costly as listing every page. Use it sparingly.
+func Count[T any](client *Client, list func(opts ...*Options) ([]T, *NextPage, error), opts ...*Options) (int, error) {
+	count := 0
+	fields := &Options{Fields: []string{"gid"}}
+	err := eachPage(client, 100, list, func(items []T) (bool, error) {
+		count += len(items)
+		return true, nil
+	}, append([]*Options{fields}, opts...)...)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// TaskCount returns the number of tasks in this project, see Count
+func (p *Project) TaskCount(client *Client, opts ...*Options) (int, error) {
+	client.trace("Counting tasks in %q", p.Name)
+
+	return Count(client, func(opts ...*Options) ([]*Task, *NextPage, error) {
+		return p.Tasks(client, opts...)
+	}, opts...)
+}
@@ -2,7 +2,10 @@ package asana
 
 import (
 	"fmt"
+	"math"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 type EnumValue struct {
@@ -62,6 +65,7 @@ const (
 	Identifier = "identifier"
 	Percentage = "percentage"
 	Custom     = "custom"
+	Duration   = "duration"
 	None       = "none"
 )
 
@@ -100,10 +104,67 @@ type CustomFieldBase struct {
 	Precision *int `json:"precision,omitempty"`
 
 	// The type of the custom field. Must be one of the given values:
-	// 'text', 'enum', 'number', 'multi_enum', 'date', 'boolean'
+	// 'text', 'enum', 'number', 'multi_enum', 'date', 'boolean', 'people'
 	ResourceSubtype FieldType `json:"resource_subtype"`
 }
 
+// validate checks that the format options are consistent with the type of
+// field being created
+func (f *CustomFieldBase) validate(hasEnumOptions bool) error {
+	if f.Name == "" {
+		return errors.New("A custom field must have a name")
+	}
+
+	switch f.ResourceSubtype {
+	case FieldTypeText, FieldTypeNumber, FieldTypeEnum, FieldTypeMultiEnum, FieldTypeDate, FieldTypeBoolean, FieldTypePeople:
+	case "":
+		return errors.New("A custom field must have a resource_subtype")
+	default:
+		return errors.Errorf("Unknown custom field type %q", f.ResourceSubtype)
+	}
+
+	isNumber := f.ResourceSubtype == FieldTypeNumber
+	isEnum := f.ResourceSubtype == FieldTypeEnum || f.ResourceSubtype == FieldTypeMultiEnum
+
+	if f.Precision != nil {
+		if !isNumber {
+			return errors.Errorf("Precision is only valid for number fields, not %s", f.ResourceSubtype)
+		}
+		if *f.Precision < 0 || *f.Precision > 6 {
+			return errors.Errorf("Precision must be between 0 and 6, not %d", *f.Precision)
+		}
+	}
+
+	if hasEnumOptions && !isEnum {
+		return errors.Errorf("Enum options are only valid for enum and multi_enum fields, not %s", f.ResourceSubtype)
+	}
+
+	switch f.Format {
+	case "", None:
+	case Currency, Identifier, Percentage, Custom, Duration:
+		if !isNumber {
+			return errors.Errorf("The %s format is only valid for number fields, not %s", f.Format, f.ResourceSubtype)
+		}
+	default:
+		return errors.Errorf("Unknown custom field format %q", f.Format)
+	}
+
+	if f.Format == Currency && f.CurrencyCode == "" {
+		return errors.New("The currency format requires a currency_code")
+	}
+	if f.Format != Currency && f.CurrencyCode != "" {
+		return errors.New("A currency_code is only valid with the currency format")
+	}
+	if f.Format != Custom && (f.CustomLabel != "" || f.CustomLabelPosition != "") {
+		return errors.New("A custom_label is only valid with the custom format")
+	}
+	if f.Format == Identifier && f.Precision != nil && *f.Precision != 0 {
+		return errors.New("The identifier format always has a precision of 0")
+	}
+
+	return nil
+}
+
 // Custom Fields store the metadata that is used in order to add user-
 // specified information to tasks in Asana. Be sure to reference the Custom
 // Fields developer documentation for more information about how custom fields
@@ -114,6 +175,9 @@ type CustomField struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	CustomFieldBase
 
 	// Only relevant for custom fields of type ‘Enum’. This array specifies
@@ -122,11 +186,67 @@ type CustomField struct {
 
 	// Read-only: This flag describes whether this custom field is available to every container
 	// in the workspace. Before project-specific custom fields, this field was always true.
+	//
+	// Fields created with CreateCustomField are added to the workspace
+	// library and are global; fields created with AddProjectLocalCustomField
+	// belong to a single project and are not.
 	IsGlobalToWorkspace *bool `json:"is_global_to_workspace,omitempty"`
 
 	// Determines whether the custom field is available for editing on a task
 	// (i.e. the field is associated with one of the task's parent projects)
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// Read-only. True if the value of the field is calculated by a formula.
+	// Formula fields cannot be written; their computed value is returned in
+	// the number_value and display_value of the CustomFieldValue.
+	IsFormulaField bool `json:"is_formula_field,omitempty"`
+
+	// Read-only. How the field is shown in the UI, which is more specific
+	// than resource_subtype: formula and custom_id fields have a number or
+	// text subtype. See the RepresentationType constants.
+	RepresentationType string `json:"representation_type,omitempty"`
+
+	// Read-only. The prefix of the values of a custom_id field, such as "ENG"
+	// for identifiers like "ENG-123".
+	IDPrefix string `json:"id_prefix,omitempty"`
+}
+
+// Representation types for CustomField.RepresentationType
+const (
+	RepresentationTypeText      = "text"
+	RepresentationTypeEnum      = "enum"
+	RepresentationTypeMultiEnum = "multi_enum"
+	RepresentationTypeNumber    = "number"
+	RepresentationTypeDate      = "date"
+	RepresentationTypePeople    = "people"
+	RepresentationTypeFormula   = "formula"
+	RepresentationTypeCustomID  = "custom_id"
+)
+
+// IsReadOnly returns true if values of this field are calculated by Asana
+// and cannot be set on tasks: formula fields, and identifier fields whose
+// values are assigned automatically
+func (f *CustomField) IsReadOnly() bool {
+	return f.IsFormulaField ||
+		f.RepresentationType == RepresentationTypeFormula ||
+		f.RepresentationType == RepresentationTypeCustomID
+}
+
+// loadReadOnly loads the fields needed by IsReadOnly if they were not
+// loaded with the custom field, as in the compact form returned for the
+// custom_fields of a task
+func (f *CustomField) loadReadOnly(client *Client) error {
+	if f.IsFormulaField || f.RepresentationType != "" {
+		return nil
+	}
+
+	loaded := &CustomField{ID: f.ID}
+	if err := loaded.Fetch(client, &Options{Fields: []string{"name", "is_formula_field", "representation_type"}}); err != nil {
+		return err
+	}
+	f.IsFormulaField = loaded.IsFormulaField
+	f.RepresentationType = loaded.RepresentationType
+	return nil
 }
 
 type CustomFieldSetting struct {
@@ -137,6 +257,13 @@ type CustomFieldSetting struct {
 
 	Project *Project `json:"project,omitempty"`
 
+	// Whether the field is shown prominently, e.g. in the columns of the
+	// project's list view. The API has no update endpoint for settings, so
+	// to change this remove the setting and add it again.
+	//
+	// Note that a custom field setting has no default value: new tasks in
+	// the project start with an empty value for the field. Use
+	// Project.ApplyDefaultFieldValue to fill in the empty values.
 	Important bool `json:"is_important,omitempty"`
 }
 
@@ -147,31 +274,111 @@ type AddCustomFieldSettingRequest struct {
 	InsertAfter  string `json:"insert_after,omitempty"`
 }
 
-func (p *Project) AddCustomFieldSetting(client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
-	client.trace("Attach custom field %v to project %q", request.CustomField, p.ID)
+// encode builds the request body, see encodeSettingRequest
+func (r *AddCustomFieldSettingRequest) encode() map[string]interface{} {
+	return encodeSettingRequest(r.CustomField, r.Important, r.InsertBefore, r.InsertAfter)
+}
 
-	// Custom request encoding
+// encodeSettingRequest builds the body of a request to add a custom field
+// setting to a project or portfolio. An insertBefore or insertAfter of "-"
+// is sent as null, which places the setting at the start or end of the
+// list.
+func encodeSettingRequest(customField interface{}, important bool, insertBefore, insertAfter string) map[string]interface{} {
 	m := map[string]interface{}{}
-	m["custom_field"] = request.CustomField
-	m["is_important"] = request.Important
+	m["custom_field"] = customField
+	m["is_important"] = important
 
-	if request.InsertAfter == "-" {
+	if insertAfter == "-" {
 		m["insert_after"] = nil
-	} else if request.InsertAfter != "" {
-		m["insert_after"] = request.InsertAfter
+	} else if insertAfter != "" {
+		m["insert_after"] = insertAfter
 	}
 
-	if request.InsertBefore == "-" {
+	if insertBefore == "-" {
 		m["insert_before"] = nil
-	} else if request.InsertBefore != "" {
-		m["insert_before"] = request.InsertBefore
+	} else if insertBefore != "" {
+		m["insert_before"] = insertBefore
 	}
+	return m
+}
+
+func (p *Project) AddCustomFieldSetting(client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
+	client.trace("Attach custom field %v to project %q", request.CustomField, p.ID)
 
 	result := &CustomFieldSetting{}
-	err := client.post(fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), m, result)
+	err := client.post(fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), request.encode(), result)
 	return result, err
 }
 
+// FetchCustomFieldSettings lists the custom field settings of this project,
+// including whether each field is important
+func (p *Project) FetchCustomFieldSettings(client *Client, opts ...*Options) ([]*CustomFieldSetting, *NextPage, error) {
+	client.trace("Listing custom field settings for project %q", p.Name)
+
+	var result []*CustomFieldSetting
+	nextPage, err := client.get(fmt.Sprintf("/projects/%s/custom_field_settings", p.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// ApplyDefaultFieldValue sets a custom field to the given value on every
+// task in this project which has no value for it, and returns the IDs of the
+// tasks which were updated. Values are given as for SetCustomField.
+//
+// Existing values are never overwritten. As the API has no default values
+// for custom fields, tasks created later are not affected; call this again
+// to fill them in. The errors for any tasks which could not be updated are
+// returned together as a MultiError.
+func (p *Project) ApplyDefaultFieldValue(client *Client, field *CustomField, value interface{}) ([]string, error) {
+	client.info("Applying default value of custom field %q in project %q", field.Name, p.Name)
+
+	if err := field.loadReadOnly(client); err != nil {
+		return nil, err
+	}
+	if field.IsReadOnly() {
+		return nil, errors.Errorf("Custom field %q is calculated by Asana and cannot be set", field.Name)
+	}
+
+	var updated []string
+	errs := &MultiError{}
+
+	list := func(opts ...*Options) ([]*Task, *NextPage, error) {
+		return p.Tasks(client, opts...)
+	}
+	fields := &Options{Fields: []string{"name", "custom_fields.display_value"}}
+	err := eachPage(client, 100, list, func(tasks []*Task) (bool, error) {
+		for _, task := range tasks {
+			if !hasEmptyValue(task, field.ID) {
+				continue
+			}
+
+			err := client.retry(func() error {
+				return task.SetCustomField(client, field, value)
+			})
+			if err != nil {
+				errs.Errors = append(errs.Errors, errors.Wrapf(err, "Set custom field on task %s", task.ID))
+				continue
+			}
+			updated = append(updated, task.ID)
+		}
+		return true, nil
+	}, fields)
+	if err != nil {
+		return updated, err
+	}
+	return updated, errs.ErrorOrNil()
+}
+
+// hasEmptyValue returns true if the custom field is present on the task
+// without a value
+func hasEmptyValue(task *Task, fieldID string) bool {
+	for _, value := range task.CustomFields {
+		if value.ID == fieldID {
+			return value.DisplayValue == nil || *value.DisplayValue == ""
+		}
+	}
+	return false
+}
+
 func (p *Project) RemoveCustomFieldSetting(client *Client, customFieldID string) error {
 	client.trace("Remove custom field %q from project %q", customFieldID, p.ID)
 
@@ -199,28 +406,24 @@ type AddProjectLocalCustomFieldRequest struct {
 	InsertAfter  string                  `json:"insert_after,omitempty"`
 }
 
+// encode builds the request body, see encodeSettingRequest
+func (r *AddProjectLocalCustomFieldRequest) encode() map[string]interface{} {
+	return encodeSettingRequest(r.CustomField, r.Important, r.InsertBefore, r.InsertAfter)
+}
+
+// AddProjectLocalCustomField creates a custom field which only exists in
+// this project, and adds it to the project. Unlike fields created with
+// CreateCustomField, the field is not added to the workspace library and
+// cannot be added to other projects.
 func (p *Project) AddProjectLocalCustomField(client *Client, request *AddProjectLocalCustomFieldRequest) (*CustomFieldSetting, error) {
 	client.trace("Attach custom field %v to project %q", request.CustomField, p.ID)
 
-	// Custom request encoding
-	m := map[string]interface{}{}
-	m["custom_field"] = request.CustomField
-	m["is_important"] = request.Important
-
-	if request.InsertAfter == "-" {
-		m["insert_after"] = nil
-	} else if request.InsertAfter != "" {
-		m["insert_after"] = request.InsertAfter
-	}
-
-	if request.InsertBefore == "-" {
-		m["insert_before"] = nil
-	} else if request.InsertBefore != "" {
-		m["insert_before"] = request.InsertBefore
+	if err := request.CustomField.validate(len(request.CustomField.EnumOptions) > 0); err != nil {
+		return nil, err
 	}
 
 	result := &CustomFieldSetting{}
-	err := client.post(fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), m, result)
+	err := client.post(fmt.Sprintf("/projects/%s/addCustomFieldSetting", p.ID), request.encode(), result)
 	return result, err
 }
 
@@ -230,12 +433,54 @@ type CreateCustomFieldRequest struct {
 	// Required: The workspace to create a custom field in.
 	Workspace string `json:"workspace"`
 
+	// Optional. When set, the field is created local to this project instead
+	// of in the workspace library, as with AddProjectLocalCustomField.
+	LocalToProject string `json:"-"`
+
 	// Conditional. Only relevant for custom fields of type enum.
 	// This array specifies the possible values which an enum custom field can adopt.
 	EnumOptions []*EnumValueBase `json:"enum_options,omitempty"`
 }
 
+// Validate checks the custom field options before creating the field
+func (r *CreateCustomFieldRequest) Validate() error {
+	if r.Workspace == "" {
+		return errors.New("A custom field must be created in a workspace")
+	}
+	return r.CustomFieldBase.validate(len(r.EnumOptions) > 0)
+}
+
+// CreateCustomField creates a custom field in the workspace library, where
+// it is global to the workspace and can be added to any project with
+// AddCustomFieldSetting. If LocalToProject is set, the field is instead
+// created in and added to that project only, and the field is loaded from
+// the new setting.
 func (c *Client) CreateCustomField(request *CreateCustomFieldRequest) (*CustomField, error) {
+	if request.LocalToProject != "" {
+		project := &Project{ID: request.LocalToProject}
+		setting, err := project.AddProjectLocalCustomField(c, &AddProjectLocalCustomFieldRequest{
+			CustomField: ProjectLocalCustomField{
+				CustomFieldBase: request.CustomFieldBase,
+				EnumOptions:     request.EnumOptions,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if setting.CustomField == nil || setting.CustomField.ID == "" {
+			return nil, errors.Errorf("Custom field setting %s for project %s has no custom field", setting.ID, request.LocalToProject)
+		}
+
+		// The setting may only contain the field in compact form
+		field := setting.CustomField
+		if field.ResourceSubtype == "" {
+			if err := field.Fetch(c); err != nil {
+				return nil, err
+			}
+		}
+		return field, nil
+	}
+
 	c.trace("Create custom field %q in workspace %s", request.Name, request.Workspace)
 
 	result := &CustomField{}
@@ -285,6 +530,250 @@ type CustomFieldValue struct {
 	PeopleValue []*User `json:"people_value,omitempty"`
 }
 
+// Update changes the name, color and enabled state of an enum option.
+//
+// Enum options cannot be deleted. Options which are no longer needed should
+// be disabled instead, which hides them from selection while preserving the
+// value on any tasks which already use them.
+func (e *EnumValue) Update(client *Client, name, color string, enabled bool) error {
+	client.trace("Updating enum option %q", e.ID)
+
+	// Custom request encoding, as enabled must be sent even when false
+	m := map[string]interface{}{
+		"enabled": enabled,
+	}
+	if name != "" {
+		m["name"] = name
+	}
+	if color != "" {
+		m["color"] = color
+	}
+
+	return client.put(fmt.Sprintf("/enum_options/%s", e.ID), m, e)
+}
+
+// ReorderEnumOption moves an enum option of this custom field relative to
+// another option. At most one of beforeOption and afterOption may be given;
+// if neither is given the option is moved to the end of the list.
+func (f *CustomField) ReorderEnumOption(client *Client, optionGID string, beforeOption, afterOption string) (*EnumValue, error) {
+	client.trace("Moving enum option %q in custom field %q", optionGID, f.ID)
+
+	if beforeOption != "" && afterOption != "" {
+		return nil, errors.New("Only one of beforeOption and afterOption may be specified")
+	}
+
+	// Custom request encoding
+	m := map[string]interface{}{
+		"enum_option": optionGID,
+	}
+	if beforeOption != "" {
+		m["before_enum_option"] = beforeOption
+	}
+	if afterOption != "" {
+		m["after_enum_option"] = afterOption
+	}
+
+	result := &EnumValue{}
+	err := client.post(fmt.Sprintf("/custom_fields/%s/enum_options/insert", f.ID), m, result)
+	return result, err
+}
+
+// SetCustomField sets the value of a custom field on this task. Values are
+// given as for UpdateTaskRequest.CustomFields, e.g. a string for a text
+// field, a number for a number field or an enum option ID for an enum field.
+//
+// Fields whose values are computed by Asana, such as formula and identifier
+// fields, are rejected without updating the task. If the field was loaded
+// in compact form, without is_formula_field and representation_type, those
+// are fetched first.
+func (t *Task) SetCustomField(client *Client, field *CustomField, value interface{}) error {
+	client.trace("Setting custom field %q on task %q", field.Name, t.Name)
+
+	if err := field.loadReadOnly(client); err != nil {
+		return err
+	}
+	if field.IsReadOnly() {
+		return errors.Errorf("Custom field %q is calculated by Asana and cannot be set", field.Name)
+	}
+
+	return t.Update(client, &UpdateTaskRequest{
+		CustomFields: map[string]interface{}{
+			field.ID: value,
+		},
+	})
+}
+
+// Identifier returns the automatically assigned value of a custom_id field,
+// including its prefix, such as "ENG-123". The second result is false if
+// the field is not an identifier field or has no value.
+func (v *CustomFieldValue) Identifier() (string, bool) {
+	if v.RepresentationType != RepresentationTypeCustomID || v.DisplayValue == nil {
+		return "", false
+	}
+	return *v.DisplayValue, true
+}
+
+// Duration returns the value of a number field with the duration format.
+// Asana stores durations as a number of minutes. The second result is false
+// if the field does not have the duration format or has no value.
+func (v *CustomFieldValue) Duration() (time.Duration, bool) {
+	if v.Format != Duration || v.NumberValue == nil {
+		return 0, false
+	}
+	return time.Duration(*v.NumberValue * float64(time.Minute)), true
+}
+
+// DurationValue converts a duration into the number of minutes stored by a
+// duration format field, rounded to the precision of the field
+func (f *CustomField) DurationValue(d time.Duration) float64 {
+	minutes := d.Minutes()
+	if f.Precision == nil {
+		return minutes
+	}
+
+	scale := math.Pow(10, float64(*f.Precision))
+	return math.Round(minutes*scale) / scale
+}
+
+// SetDurationCustomField sets the value of a duration format number field on
+// this task
+func (t *Task) SetDurationCustomField(client *Client, field *CustomField, d time.Duration) error {
+	if field.Format != Duration {
+		return errors.Errorf("Custom field %q does not have the duration format", field.Name)
+	}
+	return t.SetCustomField(client, field, field.DurationValue(d))
+}
+
+// customFieldValueFields are the fields needed to read the value of any
+// type of custom field
+var customFieldValueFields = []string{
+	"custom_fields.name",
+	"custom_fields.resource_subtype",
+	"custom_fields.is_formula_field",
+	"custom_fields.representation_type",
+	"custom_fields.text_value",
+	"custom_fields.number_value",
+	"custom_fields.boolean_value",
+	"custom_fields.date_value",
+	"custom_fields.enum_value",
+	"custom_fields.multi_enum_values",
+	"custom_fields.people_value",
+}
+
+// updateValue returns the value in the form accepted when updating a task:
+// option and user IDs for enum and people fields rather than the objects
+// they are read as. Empty values are returned as nil, which clears the
+// field, or as an empty list for multi_enum and people fields.
+func (v *CustomFieldValue) updateValue() interface{} {
+	switch v.ResourceSubtype {
+	case FieldTypeText:
+		if v.TextValue != nil {
+			return *v.TextValue
+		}
+	case FieldTypeNumber:
+		if v.NumberValue != nil {
+			return *v.NumberValue
+		}
+	case FieldTypeBoolean:
+		if v.BooleanValue != nil {
+			return *v.BooleanValue
+		}
+	case FieldTypeDate:
+		if v.DateValue != nil && v.DateValue.DateTime != nil {
+			return &DateValue{DateTime: v.DateValue.DateTime}
+		}
+		if v.DateValue != nil && v.DateValue.Date != nil {
+			return &DateValue{Date: v.DateValue.Date}
+		}
+	case FieldTypeEnum:
+		if v.EnumValue != nil {
+			return v.EnumValue.ID
+		}
+	case FieldTypeMultiEnum:
+		ids := []string{}
+		for _, option := range v.MultiEnumValues {
+			ids = append(ids, option.ID)
+		}
+		return ids
+	case FieldTypePeople:
+		ids := []string{}
+		for _, user := range v.PeopleValue {
+			ids = append(ids, user.ID)
+		}
+		return ids
+	}
+	return nil
+}
+
+// CopyCustomFieldsFrom sets the values of custom fields on this task to
+// their values on the source task, in a single update. The source values are
+// loaded from the API, so they are current. Only the fields with the given
+// IDs are copied, or all of the source task's fields if no IDs are given;
+// formula and identifier fields are skipped, as their values are calculated
+// by Asana. Empty values on the source clear the field on this task.
+//
+// The fields must be available on both tasks, e.g. through a project they
+// share, and an error is returned without updating the task if a field is
+// not set on the source.
+func (t *Task) CopyCustomFieldsFrom(client *Client, source *Task, fieldGIDs []string) error {
+	client.trace("Copying custom fields from task %q to %q", source.Name, t.Name)
+
+	loaded := &Task{}
+	_, err := client.get(fmt.Sprintf("/tasks/%s", source.ID), nil, loaded, &Options{Fields: customFieldValueFields})
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]*CustomFieldValue, len(loaded.CustomFields))
+	for _, value := range loaded.CustomFields {
+		values[value.ID] = value
+	}
+
+	if len(fieldGIDs) == 0 {
+		for _, value := range loaded.CustomFields {
+			fieldGIDs = append(fieldGIDs, value.ID)
+		}
+	}
+
+	update := make(map[string]interface{})
+	for _, id := range fieldGIDs {
+		value, ok := values[id]
+		if !ok {
+			return errors.Errorf("Task %s has no custom field %s", source.ID, id)
+		}
+		if value.IsReadOnly() {
+			continue
+		}
+		update[id] = value.updateValue()
+	}
+
+	if len(update) == 0 {
+		return nil
+	}
+	return t.Update(client, &UpdateTaskRequest{CustomFields: update})
+}
+
+// SetPeopleCustomField sets the users of a people custom field on this task,
+// given as user IDs. An empty or nil list clears the field: it is sent as an
+// empty array, because a nil slice would be encoded as null.
+func (t *Task) SetPeopleCustomField(client *Client, fieldGID string, userGIDs []string) error {
+	client.trace("Setting people custom field %s on task %q", fieldGID, t.Name)
+
+	users := []string{}
+	for _, user := range userGIDs {
+		if user == "" {
+			return errors.New("People custom field values must not contain an empty user")
+		}
+		users = append(users, user)
+	}
+
+	return t.Update(client, &UpdateTaskRequest{
+		CustomFields: map[string]interface{}{
+			fieldGID: users,
+		},
+	})
+}
+
 // Fetch loads the full details for this CustomField
 func (f *CustomField) Fetch(client *Client, options ...*Options) error {
 	client.trace("Loading details for custom field %q", f.ID)
@@ -305,25 +794,160 @@ func (w *Workspace) CustomFields(client *Client, options ...*Options) ([]*Custom
 
 // AllCustomFields repeatedly pages through all available custom fields in a workspace
 func (w *Workspace) AllCustomFields(client *Client, options ...*Options) ([]*CustomField, error) {
-	var allCustomFields []*CustomField
-	nextPage := &NextPage{}
+	return listAll(client, 50, func(opts ...*Options) ([]*CustomField, *NextPage, error) {
+		return w.CustomFields(client, opts...)
+	}, options...)
+}
+
+// TaskCustomFields returns the custom fields of this project's tasks, from
+// its custom field settings. These are distinct from the project's own
+// CustomFields, which hold the values of portfolio fields for the project.
+func (p *Project) TaskCustomFields(client *Client, opts ...*Options) ([]*CustomField, error) {
+	settings, err := listAll(client, 100, func(opts ...*Options) ([]*CustomFieldSetting, *NextPage, error) {
+		return p.FetchCustomFieldSettings(client, opts...)
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*CustomField
+	for _, setting := range settings {
+		if setting.CustomField != nil {
+			result = append(result, setting.CustomField)
+		}
+	}
+	return result, nil
+}
+
+// CustomFieldUsage describes a custom field of a workspace and the projects
+// which use it
+type CustomFieldUsage struct {
+	CustomField *CustomField
+
+	// True if the field is in the workspace's shared library, and so can be
+	// added to any project. Fields which are not belong to a single project.
+	InLibrary bool
 
-	var customFields []*CustomField
-	var err error
+	// The projects whose tasks have the field
+	Projects []*Project
+}
+
+// CustomFieldUsage lists every custom field of this workspace with the
+// projects which use it, for auditing which fields are in use. Fields in the
+// shared library which no project uses are included with no projects, and
+// fields which are local to a project are included with that project.
+//
+// The custom field settings of every project in the workspace are loaded,
+// so this makes a request for each project.
+func (w *Workspace) CustomFieldUsage(client *Client) ([]*CustomFieldUsage, error) {
+	fieldOptions := &Options{Fields: []string{"name", "resource_subtype", "is_global_to_workspace"}}
+
+	fields, err := w.AllCustomFields(client, fieldOptions)
+	if err != nil {
+		return nil, err
+	}
 
-	for nextPage != nil {
-		page := &Options{
-			Limit:  50,
-			Offset: nextPage.Offset,
+	var result []*CustomFieldUsage
+	byID := make(map[string]*CustomFieldUsage)
+	add := func(field *CustomField) *CustomFieldUsage {
+		if usage, ok := byID[field.ID]; ok {
+			return usage
 		}
+		usage := &CustomFieldUsage{
+			CustomField: field,
+			InLibrary:   IsTrue(field.IsGlobalToWorkspace),
+		}
+		byID[field.ID] = usage
+		result = append(result, usage)
+		return usage
+	}
+
+	for _, field := range fields {
+		add(field)
+	}
+
+	projects, err := w.AllProjects(client, &Options{Fields: []string{"name"}})
+	if err != nil {
+		return nil, err
+	}
 
-		allOptions := append([]*Options{page}, options...)
-		customFields, nextPage, err = w.CustomFields(client, allOptions...)
+	for _, project := range projects {
+		projectFields, err := project.TaskCustomFields(client, &Options{
+			Fields: []string{"custom_field.name", "custom_field.resource_subtype", "custom_field.is_global_to_workspace"},
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		allCustomFields = append(allCustomFields, customFields...)
+		for _, field := range projectFields {
+			usage := add(field)
+			usage.Projects = append(usage.Projects, project)
+		}
+	}
+	return result, nil
+}
+
+// CustomFieldSettings returns the custom field settings attached to this
+// portfolio. These describe the portfolio-level fields whose values are
+// recorded on each project in the portfolio, and are distinct from the
+// custom field settings of a project, which apply to its tasks.
+//
+// Like all portfolio requests, this fails with a PremiumRequiredError
+// outside business and enterprise plans.
+func (p *Portfolio) CustomFieldSettings(client *Client, options ...*Options) ([]*CustomFieldSetting, *NextPage, error) {
+	client.trace("Listing custom field settings for portfolio %q", p.ID)
+	var result []*CustomFieldSetting
+
+	// Make the request
+	nextPage, err := client.get(fmt.Sprintf("/portfolios/%s/custom_field_settings", p.ID), nil, &result, options...)
+	return result, nextPage, err
+}
+
+// AddCustomFieldSetting attaches a custom field to this portfolio. The field
+// values are set on the member projects of the portfolio. This fails with a
+// PremiumRequiredError outside business and enterprise plans.
+func (p *Portfolio) AddCustomFieldSetting(client *Client, request *AddCustomFieldSettingRequest) (*CustomFieldSetting, error) {
+	client.trace("Attach custom field %v to portfolio %q", request.CustomField, p.ID)
+
+	result := &CustomFieldSetting{}
+	err := client.post(fmt.Sprintf("/portfolios/%s/addCustomFieldSetting", p.ID), request.encode(), result)
+	return result, err
+}
+
+// PortfolioFieldValue is the value recorded on a project for a custom field
+// attached to one of its parent portfolios. It has the same form as a
+// CustomFieldValue, but is a distinct type so that it is not confused with
+// the values of a project's own custom fields.
+type PortfolioFieldValue struct {
+	CustomFieldValue
+}
+
+// PortfolioCustomFieldValues loads the values recorded on this project for
+// custom fields attached to its parent portfolios. These are the values of
+// portfolio-level fields, not the custom field settings of the project. The
+// project itself is not modified.
+func (p *Project) PortfolioCustomFieldValues(client *Client) ([]*PortfolioFieldValue, error) {
+	client.trace("Loading portfolio custom field values for project %q", p.ID)
+
+	result := &struct {
+		CustomFields []*PortfolioFieldValue `json:"custom_fields"`
+	}{}
+	_, err := client.get(fmt.Sprintf("/projects/%s", p.ID), nil, result, &Options{Fields: customFieldValueFields})
+	if err != nil {
+		return nil, err
+	}
+	return result.CustomFields, nil
+}
+
+// GID returns the globally unique ID of this CustomField
+func (f *CustomField) GID() string {
+	return f.ID
+}
+
+// Type returns the resource type of this CustomField
+func (f *CustomField) Type() string {
+	if f.ResourceType != "" {
+		return f.ResourceType
 	}
-	return allCustomFields, nil
+	return "custom_field"
 }
@@ -2,7 +2,9 @@ package asana
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
+	"time"
 )
 
 func TestCustomFieldBase_Precision_ParseZero(t *testing.T) {
@@ -47,3 +49,293 @@ func TestCustomFieldBase_Precision_SerializeZero(t *testing.T) {
 	}
 
 }
+
+func TestCreateCustomFieldRequest_Validate(t *testing.T) {
+	precision := 2
+
+	valid := []*CreateCustomFieldRequest{
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Notes", ResourceSubtype: FieldTypeText}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Cost", ResourceSubtype: FieldTypeNumber, Precision: &precision, Format: Currency, CurrencyCode: "EUR"}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Estimate", ResourceSubtype: FieldTypeNumber, Format: Duration}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Stage", ResourceSubtype: FieldTypeMultiEnum}, EnumOptions: []*EnumValueBase{{Name: "One"}}},
+	}
+	for _, request := range valid {
+		if err := request.Validate(); err != nil {
+			t.Errorf("Expected %q to be valid, but saw %v", request.Name, err)
+		}
+	}
+
+	invalid := []*CreateCustomFieldRequest{
+		{CustomFieldBase: CustomFieldBase{Name: "No workspace", ResourceSubtype: FieldTypeText}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Text precision", ResourceSubtype: FieldTypeText, Precision: &precision}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Text options", ResourceSubtype: FieldTypeText}, EnumOptions: []*EnumValueBase{{Name: "One"}}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "No currency", ResourceSubtype: FieldTypeNumber, Format: Currency}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "Date format", ResourceSubtype: FieldTypeDate, Format: Percentage}},
+		{Workspace: "1", CustomFieldBase: CustomFieldBase{Name: "No type"}},
+	}
+	for _, request := range invalid {
+		if err := request.Validate(); err == nil {
+			t.Errorf("Expected %q to be invalid", request.Name)
+		}
+	}
+}
+
+func TestCustomFieldValue_Duration(t *testing.T) {
+	value := &CustomFieldValue{}
+	if err := json.Unmarshal([]byte(`{"format": "duration", "number_value": 90.5, "precision": 1}`), value); err != nil {
+		t.Fatal(err)
+	}
+
+	d, ok := value.Duration()
+	if !ok || d != 90*time.Minute+30*time.Second {
+		t.Errorf("Expected a duration of 90m30s, but saw %v", d)
+	}
+
+	if minutes := value.DurationValue(2*time.Hour + 20*time.Second); minutes != 120.3 {
+		t.Errorf("Expected 120.3 minutes, but saw %v", minutes)
+	}
+
+	value.Format = Percentage
+	if _, ok := value.Duration(); ok {
+		t.Error("Expected no duration for a percentage field")
+	}
+}
+
+func TestCustomFieldValue_Identifier(t *testing.T) {
+	value := &CustomFieldValue{}
+	if err := json.Unmarshal([]byte(`{"resource_subtype": "text", "representation_type": "custom_id", "id_prefix": "ENG", "display_value": "ENG-123"}`), value); err != nil {
+		t.Fatal(err)
+	}
+
+	if id, ok := value.Identifier(); !ok || id != "ENG-123" {
+		t.Errorf("Expected identifier ENG-123, but saw %q", id)
+	}
+	if !value.IsReadOnly() {
+		t.Error("Expected identifier fields to be read-only")
+	}
+}
+
+func TestTask_CopyCustomFieldsFrom(t *testing.T) {
+	var update map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/1":
+			w.Write([]byte(`{"data": {"gid": "1", "custom_fields": [
+				{"gid": "10", "resource_subtype": "text", "text_value": "Copied"},
+				{"gid": "11", "resource_subtype": "number", "number_value": 0},
+				{"gid": "12", "resource_subtype": "enum", "enum_value": {"gid": "120"}},
+				{"gid": "13", "resource_subtype": "multi_enum", "multi_enum_values": [{"gid": "130"}, {"gid": "131"}]},
+				{"gid": "14", "resource_subtype": "date", "date_value": {"date": "2024-03-01"}},
+				{"gid": "15", "resource_subtype": "people", "people_value": []},
+				{"gid": "16", "resource_subtype": "text", "text_value": null},
+				{"gid": "17", "resource_subtype": "number", "is_formula_field": true, "number_value": 5}
+			]}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/tasks/2":
+			var body struct {
+				Data struct {
+					CustomFields map[string]interface{} `json:"custom_fields"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			update = body.Data.CustomFields
+			w.Write([]byte(`{"data": {"gid": "2"}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	target := &Task{ID: "2"}
+	if err := target.CopyCustomFieldsFrom(client, &Task{ID: "1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"10": `"Copied"`,
+		"11": `0`,
+		"12": `"120"`,
+		"13": `["130","131"]`,
+		"14": `{"date":"2024-03-01"}`,
+		"15": `[]`,
+		"16": `null`,
+	}
+	for id, value := range expected {
+		actual, ok := update[id]
+		data, _ := json.Marshal(actual)
+		if !ok || string(data) != value {
+			t.Errorf("Expected field %s to be sent as %s, but saw %s", id, value, data)
+		}
+	}
+	if _, ok := update["17"]; ok {
+		t.Error("Expected the formula field to be skipped")
+	}
+
+	if err := target.CopyCustomFieldsFrom(client, &Task{ID: "1"}, []string{"99"}); err == nil {
+		t.Error("Expected an error for a field which is not on the source task")
+	}
+}
+
+func TestWorkspace_CustomFieldUsage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1/custom_fields":
+			w.Write([]byte(`{"data": [
+				{"gid": "10", "name": "Priority", "is_global_to_workspace": true},
+				{"gid": "11", "name": "Unused", "is_global_to_workspace": true}
+			]}`))
+		case "/workspaces/1/projects":
+			w.Write([]byte(`{"data": [{"gid": "100", "name": "Roadmap"}]}`))
+		case "/projects/100/custom_field_settings":
+			w.Write([]byte(`{"data": [
+				{"gid": "1000", "custom_field": {"gid": "10", "name": "Priority", "is_global_to_workspace": true}},
+				{"gid": "1001", "custom_field": {"gid": "12", "name": "Local", "is_global_to_workspace": false}}
+			]}`))
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+	})
+
+	usage, err := (&Workspace{ID: "1"}).CustomFieldUsage(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 3 {
+		t.Fatalf("Expected three fields, but saw %d", len(usage))
+	}
+
+	expected := []struct {
+		id        string
+		inLibrary bool
+		projects  int
+	}{
+		{"10", true, 1},
+		{"11", true, 0},
+		{"12", false, 1},
+	}
+	for i, e := range expected {
+		u := usage[i]
+		if u.CustomField.ID != e.id || u.InLibrary != e.inLibrary || len(u.Projects) != e.projects {
+			t.Errorf("Expected field %s in library %v with %d projects, but saw %s %v %d", e.id, e.inLibrary, e.projects, u.CustomField.ID, u.InLibrary, len(u.Projects))
+		}
+	}
+}
+
+func TestTask_SetPeopleCustomField(t *testing.T) {
+	var sent json.RawMessage
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data struct {
+				CustomFields map[string]json.RawMessage `json:"custom_fields"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		sent = body.Data.CustomFields["10"]
+		w.Write([]byte(`{"data": {"gid": "1"}}`))
+	})
+
+	tests := []struct {
+		users    []string
+		expected string
+	}{
+		{nil, `[]`},
+		{[]string{}, `[]`},
+		{[]string{"100"}, `["100"]`},
+		{[]string{"100", "101"}, `["100","101"]`},
+	}
+
+	task := &Task{ID: "1"}
+	for _, test := range tests {
+		sent = nil
+		if err := task.SetPeopleCustomField(client, "10", test.users); err != nil {
+			t.Fatal(err)
+		}
+		if string(sent) != test.expected {
+			t.Errorf("Expected %v to be sent as %s, but saw %s", test.users, test.expected, sent)
+		}
+	}
+
+	if err := task.SetPeopleCustomField(client, "10", []string{""}); err == nil {
+		t.Error("Expected an error for an empty user")
+	}
+}
+
+func TestTask_SetCustomField_CompactFormula(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/custom_fields/10":
+			w.Write([]byte(`{"data": {"gid": "10", "name": "Total", "is_formula_field": true, "representation_type": "formula"}}`))
+		case "/custom_fields/11":
+			w.Write([]byte(`{"data": {"gid": "11", "name": "ID", "representation_type": "custom_id"}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	task := &Task{ID: "1"}
+	for _, field := range []*CustomField{{ID: "10", CustomFieldBase: CustomFieldBase{Name: "Total"}}, {ID: "11", CustomFieldBase: CustomFieldBase{Name: "ID"}}} {
+		if err := task.SetCustomField(client, field, 1); err == nil {
+			t.Errorf("Expected an error setting read only field %s", field.ID)
+		}
+	}
+}
+
+func TestClient_CreateCustomField_LocalToProject(t *testing.T) {
+	compact := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/1/addCustomFieldSetting":
+			if compact {
+				w.Write([]byte(`{"data": {"gid": "5", "custom_field": {"gid": "7", "name": "Effort"}}}`))
+			} else {
+				w.Write([]byte(`{"data": {"gid": "5"}}`))
+			}
+		case "/custom_fields/7":
+			w.Write([]byte(`{"data": {"gid": "7", "name": "Effort", "resource_subtype": "number"}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	})
+
+	request := &CreateCustomFieldRequest{
+		CustomFieldBase: CustomFieldBase{Name: "Effort", ResourceSubtype: FieldTypeNumber},
+		Workspace:       "2",
+		LocalToProject:  "1",
+	}
+
+	field, err := client.CreateCustomField(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field.ID != "7" || field.ResourceSubtype != FieldTypeNumber {
+		t.Errorf("Expected the custom field to be loaded, but saw %+v", field)
+	}
+
+	compact = false
+	if field, err := client.CreateCustomField(request); err == nil {
+		t.Errorf("Expected an error for a setting without a custom field, but saw %+v", field)
+	}
+}
+
+func TestProject_PortfolioCustomFieldValues(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/1" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"data": {"gid": "1", "custom_fields": [{"gid": "7", "name": "Budget", "resource_subtype": "number", "number_value": 1200}]}}`))
+	})
+
+	project := &Project{ID: "1"}
+	values, err := project.PortfolioCustomFieldValues(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[0].ID != "7" || values[0].NumberValue == nil || *values[0].NumberValue != 1200 {
+		t.Errorf("Expected the portfolio field value, but saw %+v", values)
+	}
+	if project.CustomFields != nil {
+		t.Errorf("Expected the project not to be modified, but saw %+v", project.CustomFields)
+	}
+}
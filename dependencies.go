@@ -0,0 +1,83 @@
+package asana
+
+import (
+	"fmt"
+)
+
+// maxDependencyDepth is the number of levels of the dependency graph
+// searched by DetectDependencyCycle
+const maxDependencyDepth = 10
+
+// FetchDependencies returns the compact records of the tasks this task depends on
+func (t *Task) FetchDependencies(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
+	client.trace("Listing dependencies of task %q", t.ID)
+
+	var result []*Task
+
+	// Make the request
+	nextPage, err := client.get(fmt.Sprintf("/tasks/%s/dependencies", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// DetectDependencyCycle checks whether making a task depend on the given
+// tasks would create a dependency cycle, which the API rejects. The existing
+// dependencies of those tasks are followed transitively, up to 10 levels
+// deep.
+//
+// If a cycle would be created, the IDs of the tasks in the cycle are
+// returned in dependency order, starting and ending with taskGID. Otherwise
+// a nil slice is returned.
+func DetectDependencyCycle(client *Client, taskGID string, dependsOnGIDs []string) ([]string, error) {
+	// parents records the task through which each task was first reached,
+	// to recover the path of a cycle
+	parents := map[string]string{}
+	var level []string
+	for _, id := range dependsOnGIDs {
+		if id == taskGID {
+			return []string{taskGID, taskGID}, nil
+		}
+		if _, seen := parents[id]; !seen {
+			parents[id] = taskGID
+			level = append(level, id)
+		}
+	}
+
+	for depth := 0; depth < maxDependencyDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, id := range level {
+			task := &Task{ID: id}
+			dependencies, _, err := task.FetchDependencies(client, &Options{Limit: 100})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, dependency := range dependencies {
+				if dependency.ID == taskGID {
+					return cyclePath(parents, taskGID, id), nil
+				}
+				if _, seen := parents[dependency.ID]; !seen {
+					parents[dependency.ID] = id
+					next = append(next, dependency.ID)
+				}
+			}
+		}
+		level = next
+	}
+	return nil, nil
+}
+
+// cyclePath follows the parents of the last task back to the root task,
+// returning the path from the root through the last task and back to the
+// root
+func cyclePath(parents map[string]string, root, last string) []string {
+	var reversed []string
+	for id := last; id != root; id = parents[id] {
+		reversed = append(reversed, id)
+	}
+
+	path := []string{root}
+	for i := len(reversed) - 1; i >= 0; i-- {
+		path = append(path, reversed[i])
+	}
+	return append(path, root)
+}
@@ -0,0 +1,99 @@
+package asana
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCyclePath(t *testing.T) {
+	// 1 would depend on 2, which depends on 3, which depends on 1
+	parents := map[string]string{"2": "1", "3": "2"}
+
+	if path := cyclePath(parents, "1", "3"); !reflect.DeepEqual(path, []string{"1", "2", "3", "1"}) {
+		t.Errorf("Unexpected cycle path %v", path)
+	}
+}
+
+// dependencyGraphClient serves the dependencies of each task from a graph,
+// counting the requests made for each task
+func dependencyGraphClient(t *testing.T, graph map[string][]string, fetched map[string]int) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/dependencies")
+		fetched[id]++
+
+		var dependencies []*Task
+		for _, dependency := range graph[id] {
+			dependencies = append(dependencies, &Task{ID: dependency})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": dependencies})
+	})
+}
+
+func TestDetectDependencyCycle(t *testing.T) {
+	// A chain of tasks 2, 3, ... n, where each depends on the next and the
+	// last depends on 1
+	chain := func(n int) map[string][]string {
+		graph := map[string][]string{}
+		for i := 2; i < n; i++ {
+			graph[strconv.Itoa(i)] = []string{strconv.Itoa(i + 1)}
+		}
+		graph[strconv.Itoa(n)] = []string{"1"}
+		return graph
+	}
+
+	var deepest []string
+	for i := 1; i <= maxDependencyDepth+1; i++ {
+		deepest = append(deepest, strconv.Itoa(i))
+	}
+
+	tests := []struct {
+		name      string
+		graph     map[string][]string
+		dependsOn []string
+		expected  []string
+	}{
+		{"self", nil, []string{"1"}, []string{"1", "1"}},
+		{"cycle", map[string][]string{"2": {"3"}, "3": {"1"}}, []string{"2"}, []string{"1", "2", "3", "1"}},
+		{"diamond", map[string][]string{"2": {"4"}, "3": {"4"}, "4": {"5"}}, []string{"2", "3"}, nil},
+		{"diamond cycle", map[string][]string{"2": {"4"}, "3": {"4"}, "4": {"1"}}, []string{"2", "3"}, []string{"1", "2", "4", "1"}},
+		{"deepest chain", chain(maxDependencyDepth + 1), []string{"2"}, append(deepest, "1")},
+		{"chain beyond depth", chain(maxDependencyDepth + 2), []string{"2"}, nil},
+	}
+
+	for _, test := range tests {
+		fetched := map[string]int{}
+		client := dependencyGraphClient(t, test.graph, fetched)
+
+		cycle, err := DetectDependencyCycle(client, "1", test.dependsOn)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(cycle, test.expected) {
+			t.Errorf("%s: expected cycle %v, but saw %v", test.name, test.expected, cycle)
+		}
+		for id, count := range fetched {
+			if count > 1 {
+				t.Errorf("%s: expected the dependencies of %s to be fetched once, but saw %d requests", test.name, id, count)
+			}
+		}
+		if len(fetched) > maxDependencyDepth {
+			t.Errorf("%s: expected at most %d levels to be fetched, but saw %d requests", test.name, maxDependencyDepth, len(fetched))
+		}
+	}
+}
+
+func TestDetectDependencyCycle_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors": [{"message": "Not found"}]}`)
+	})
+
+	if cycle, err := DetectDependencyCycle(client, "1", []string{"2"}); err == nil {
+		t.Errorf("Expected an error, but saw %v", cycle)
+	}
+}
@@ -0,0 +1,108 @@
+package asana
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Deprecation describes an upcoming breaking change to the API, announced
+// in the Asana-Change header of a response
+type Deprecation struct {
+	// The name of the change, which can be given in the Enable or Disable
+	// options to opt in to or out of it early
+	Name string
+
+	// A link to the announcement of the change
+	Info string
+
+	// Whether the request which returned the header is affected by the change
+	Affected bool
+
+	// The URL of the first request which returned the header
+	URL string
+}
+
+// OnDeprecation sets a function which is called whenever a response carries
+// an Asana-Change header, with the name of the change, the link to its
+// announcement and the URL of the request which was affected. It is called
+// for every response with the header, not only the first.
+func (c *Client) OnDeprecation(fn func(change, info, affectedURL string)) {
+	c.deprecationLock.Lock()
+	defer c.deprecationLock.Unlock()
+
+	c.onDeprecation = fn
+}
+
+// Deprecations returns the changes announced in Asana-Change headers since
+// the client was created, once per change
+func (c *Client) Deprecations() []Deprecation {
+	c.deprecationLock.Lock()
+	defer c.deprecationLock.Unlock()
+
+	return append([]Deprecation(nil), c.deprecations...)
+}
+
+// recordDeprecations collects the Asana-Change headers of a response
+func (c *Client) recordDeprecations(resp *http.Response) {
+	changes := parseAsanaChange(resp.Header.Values("Asana-Change"))
+	if len(changes) == 0 {
+		return
+	}
+
+	requestURL := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		requestURL = resp.Request.URL.String()
+	}
+
+	c.deprecationLock.Lock()
+	fn := c.onDeprecation
+	for _, change := range changes {
+		change.URL = requestURL
+
+		known := false
+		for _, existing := range c.deprecations {
+			if existing.Name == change.Name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			c.info("API change %q announced: %s", change.Name, change.Info)
+			c.deprecations = append(c.deprecations, change)
+		}
+	}
+	c.deprecationLock.Unlock()
+
+	if fn != nil {
+		for _, change := range changes {
+			fn(change.Name, change.Info, requestURL)
+		}
+	}
+}
+
+// parseAsanaChange parses Asana-Change header values of the form
+// "name=new_sections;info=https://...;affected=true". A single header may
+// list several changes separated by commas.
+func parseAsanaChange(values []string) []Deprecation {
+	var result []Deprecation
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			change := Deprecation{}
+			for _, param := range strings.Split(entry, ";") {
+				key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				switch strings.ToLower(key) {
+				case "name":
+					change.Name = val
+				case "info":
+					change.Info = val
+				case "affected":
+					change.Affected = strings.EqualFold(val, "true")
+				}
+			}
+			if change.Name != "" {
+				result = append(result, change)
+			}
+		}
+	}
+	return result
+}
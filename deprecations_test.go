@@ -0,0 +1,42 @@
+package asana
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRecordDeprecations(t *testing.T) {
+	client := &Client{}
+
+	var calls []string
+	client.OnDeprecation(func(change, info, affectedURL string) {
+		calls = append(calls, change+" "+info+" "+affectedURL)
+	})
+
+	u, _ := url.Parse("https://app.asana.com/api/1.0/tasks/1")
+	resp := &http.Response{
+		Header:  http.Header{},
+		Request: &http.Request{URL: u},
+	}
+	resp.Header.Add("Asana-Change", "name=new_sections;info=https://asa.na/1;affected=true,name=string_ids;info=https://asa.na/2")
+	resp.Header.Add("Asana-Change", "name=new_user_task_lists;info=https://asa.na/3")
+
+	client.recordDeprecations(resp)
+	client.recordDeprecations(resp)
+
+	deprecations := client.Deprecations()
+	if len(deprecations) != 3 {
+		t.Fatalf("Expected 3 deprecations, but saw %+v", deprecations)
+	}
+	if d := deprecations[0]; d.Name != "new_sections" || d.Info != "https://asa.na/1" || !d.Affected || d.URL != u.String() {
+		t.Errorf("Unexpected deprecation %+v", d)
+	}
+	if deprecations[1].Affected {
+		t.Error("Expected string_ids not to be affected")
+	}
+
+	if len(calls) != 6 || calls[0] != "new_sections https://asa.na/1 "+u.String() {
+		t.Errorf("Unexpected hook calls %v", calls)
+	}
+}
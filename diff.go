@@ -0,0 +1,136 @@
+package asana
+
+import (
+	"reflect"
+	"time"
+)
+
+// FieldChange describes a field which differs between two snapshots of an
+// object. Old and New hold the compared values, which are nil when the field
+// was not set.
+type FieldChange struct {
+	// The JSON name of the field. Custom fields are named
+	// "custom_fields.<gid>".
+	Field string
+
+	Old interface{}
+	New interface{}
+}
+
+// TaskDiff compares two snapshots of a task and returns the fields which have
+// changed, for example to decide which changes to push to an external system
+// when syncing.
+//
+// The name, notes, assignee, due and start dates and times, completion state
+// and custom field values are compared. Users, enum options and other
+// objects are compared by ID, so compact and expanded records of the same
+// object are not reported as changes. Either snapshot may be nil.
+func TaskDiff(old, new *Task) []FieldChange {
+	if old == nil {
+		old = &Task{}
+	}
+	if new == nil {
+		new = &Task{}
+	}
+
+	var changes []FieldChange
+	compare := func(field string, o, n interface{}) {
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, FieldChange{Field: field, Old: o, New: n})
+		}
+	}
+
+	compare("name", stringValue(old.Name), stringValue(new.Name))
+	compare("notes", stringValue(old.Notes), stringValue(new.Notes))
+	compare("html_notes", stringValue(old.HTMLNotes), stringValue(new.HTMLNotes))
+	compare("assignee", userID(old.Assignee), userID(new.Assignee))
+	compare("due_on", dateValue(old.DueOn), dateValue(new.DueOn))
+	compare("due_at", timeValue(old.DueAt), timeValue(new.DueAt))
+	compare("start_on", dateValue(old.StartOn), dateValue(new.StartOn))
+	compare("start_at", timeValue(old.StartAt), timeValue(new.StartAt))
+	compare("completed", IsTrue(old.Completed), IsTrue(new.Completed))
+
+	// Custom fields are compared by ID, in the order they appear on the new
+	// task followed by any which have been removed
+	oldValues := make(map[string]*CustomFieldValue)
+	for _, value := range old.CustomFields {
+		oldValues[value.ID] = value
+	}
+	seen := make(map[string]bool)
+	for _, value := range new.CustomFields {
+		seen[value.ID] = true
+		compare("custom_fields."+value.ID, customFieldValue(oldValues[value.ID]), customFieldValue(value))
+	}
+	for _, value := range old.CustomFields {
+		if !seen[value.ID] {
+			compare("custom_fields."+value.ID, customFieldValue(value), nil)
+		}
+	}
+
+	return changes
+}
+
+func stringValue(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func userID(u *User) interface{} {
+	if u == nil {
+		return nil
+	}
+	return u.ID
+}
+
+func dateValue(d *Date) interface{} {
+	if d == nil {
+		return nil
+	}
+	return time.Time(*d).Format(dateLayout)
+}
+
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// customFieldValue returns a comparable representation of the value of a
+// custom field, or nil if no value is set
+func customFieldValue(v *CustomFieldValue) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	switch {
+	case v.TextValue != nil:
+		return stringValue(*v.TextValue)
+	case v.NumberValue != nil:
+		return *v.NumberValue
+	case v.BooleanValue != nil:
+		return *v.BooleanValue
+	case v.EnumValue != nil:
+		return v.EnumValue.ID
+	case len(v.MultiEnumValues) > 0:
+		var ids []string
+		for _, value := range v.MultiEnumValues {
+			ids = append(ids, value.ID)
+		}
+		return ids
+	case len(v.PeopleValue) > 0:
+		var ids []string
+		for _, user := range v.PeopleValue {
+			ids = append(ids, user.ID)
+		}
+		return ids
+	case v.DateValue != nil:
+		if v.DateValue.DateTime != nil {
+			return timeValue(v.DateValue.DateTime)
+		}
+		return dateValue(v.DateValue.Date)
+	}
+	return nil
+}
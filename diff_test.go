@@ -0,0 +1,69 @@
+package asana
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTaskDiff(t *testing.T) {
+	old := &Task{}
+	if err := json.Unmarshal([]byte(`
+{
+	"gid": "1",
+	"name": "Task",
+	"notes": "Notes",
+	"assignee": {"gid": "10", "name": "Someone"},
+	"due_on": "2024-03-01",
+	"start_at": "2024-02-28T09:00:00Z",
+	"completed": false,
+	"custom_fields": [
+		{"gid": "100", "resource_subtype": "enum", "enum_value": {"gid": "101", "name": "High"}},
+		{"gid": "200", "resource_subtype": "number", "number_value": 1},
+		{"gid": "300", "resource_subtype": "text", "text_value": "Removed"}
+	]
+}
+`), old); err != nil {
+		t.Fatal(err)
+	}
+
+	new := &Task{}
+	if err := json.Unmarshal([]byte(`
+{
+	"gid": "1",
+	"name": "Task",
+	"notes": "Notes",
+	"assignee": {"gid": "10"},
+	"due_on": "2024-03-02",
+	"start_at": "2024-02-28T10:30:00+01:00",
+	"completed": true,
+	"custom_fields": [
+		{"gid": "100", "resource_subtype": "enum", "enum_value": {"gid": "101"}},
+		{"gid": "200", "resource_subtype": "number", "number_value": 2}
+	]
+}
+`), new); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FieldChange{
+		{Field: "due_on", Old: "2024-03-01", New: "2024-03-02"},
+		{Field: "start_at", Old: time.Date(2024, 2, 28, 9, 0, 0, 0, time.UTC), New: time.Date(2024, 2, 28, 9, 30, 0, 0, time.UTC)},
+		{Field: "completed", Old: false, New: true},
+		{Field: "custom_fields.200", Old: 1.0, New: 2.0},
+		{Field: "custom_fields.300", Old: "Removed", New: nil},
+	}
+
+	if changes := TaskDiff(old, new); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes %+v, but saw %+v", expected, changes)
+	}
+}
+
+func TestTaskDiff_Unchanged(t *testing.T) {
+	task := &Task{ID: "1", TaskBase: TaskBase{Name: "Task"}}
+
+	if changes := TaskDiff(task, task); len(changes) != 0 {
+		t.Errorf("Expected no changes, but saw %+v", changes)
+	}
+}
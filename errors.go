@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,11 +27,14 @@ func (r *Response) Error(resp *http.Response, requestID xid.ID) error {
 	retryHeader := resp.Header.Get("Retry-After")
 	if retryHeader != "" {
 		retryAfter, err := strconv.ParseInt(retryHeader, 10, 64)
-		if err != nil {
+		if err == nil {
 			asanaError.RetryAfter = time.Duration(retryAfter) * time.Second
 		}
 	}
 
+	if asanaError.StatusCode == http.StatusPaymentRequired {
+		return &PremiumRequiredError{Err: asanaError}
+	}
 	return asanaError
 }
 
@@ -57,6 +61,37 @@ func IsAsanaError(err error) (*Error, bool) {
 	return nil, false
 }
 
+// PremiumRequiredError is returned when a request is made for a feature
+// which is not available on the plan of the workspace, such as portfolios,
+// goals or advanced search, which require a premium, business or enterprise
+// plan. The API responds to these requests with 402 Payment Required.
+type PremiumRequiredError struct {
+	Err *Error
+}
+
+func (err *PremiumRequiredError) Error() string {
+	return fmt.Sprintf("%s (a premium Asana plan is required)", err.Err.Error())
+}
+
+// Cause returns the underlying API error, so that IsAsanaError and the other
+// error checks work as for any other API error
+func (err *PremiumRequiredError) Cause() error {
+	return err.Err
+}
+
+// Unwrap returns the underlying API error, so that errors.As finds it as an
+// *Error
+func (err *PremiumRequiredError) Unwrap() error {
+	return err.Err
+}
+
+// IsPremiumRequired checks if the provided error indicates that a feature is
+// not available on the plan of the workspace
+func IsPremiumRequired(err error) bool {
+	var e *PremiumRequiredError
+	return errors.As(err, &e)
+}
+
 func (err *Error) withType(statusCode int, errorType string) *Error {
 	err.StatusCode = statusCode
 	err.Type = errorType
@@ -119,3 +154,32 @@ func RetryAfter(err error) time.Duration {
 	}
 	return time.Minute
 }
+
+// MultiError collects the errors from a series of requests made for a single
+// operation, such as adding a long list of followers in several chunks.
+type MultiError struct {
+	Errors []error
+}
+
+func (err *MultiError) Error() string {
+	if len(err.Errors) == 1 {
+		return err.Errors[0].Error()
+	}
+
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "%d errors occurred:", len(err.Errors))
+	for _, e := range err.Errors {
+		b.WriteString("\n\t* ")
+		b.WriteString(e.Error())
+	}
+	return b.String()
+}
+
+// ErrorOrNil returns nil if no errors were collected, so the MultiError can
+// be returned directly as an error
+func (err *MultiError) ErrorOrNil() error {
+	if err == nil || len(err.Errors) == 0 {
+		return nil
+	}
+	return err
+}
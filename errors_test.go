@@ -1,9 +1,12 @@
 package asana
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/rs/xid"
 )
 
 func TestCauseWrappedError(t *testing.T) {
@@ -22,3 +25,35 @@ func TestCauseWrappedError(t *testing.T) {
 		t.Error("Expected double-wrapped error to be recoverable")
 	}
 }
+
+func TestPremiumRequiredError(t *testing.T) {
+	var err error = &PremiumRequiredError{Err: &Error{StatusCode: 402, Message: "Payment Required"}}
+	wrapped := errors.Wrap(err, "List portfolios")
+
+	if !IsPremiumRequired(err) || !IsPremiumRequired(wrapped) {
+		t.Error("Expected premium required error to be detected")
+	}
+	if e, ok := IsAsanaError(wrapped); !ok || e.StatusCode != 402 {
+		t.Error("Expected premium required error to be an Asana error")
+	}
+	var asanaError *Error
+	if !errors.As(wrapped, &asanaError) || asanaError.StatusCode != 402 {
+		t.Error("Expected errors.As to find the Asana error of a premium required error")
+	}
+	if IsPremiumRequired(&Error{StatusCode: 403}) {
+		t.Error("Expected other errors not to be premium required errors")
+	}
+}
+
+func TestResponseError_RetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Header:     http.Header{"Retry-After": {"30"}},
+	}
+
+	err := (&Response{}).Error(resp, xid.New())
+	if e, ok := IsAsanaError(err); !ok || e.RetryAfter != 30*time.Second {
+		t.Errorf("Expected a retry after 30s, but saw %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package asana
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EventResource is the compact form of a resource in an event
+type EventResource struct {
+	ID              string `json:"gid,omitempty"`
+	ResourceType    string `json:"resource_type,omitempty"`
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+	Name            string `json:"name,omitempty"`
+}
+
+// EventChange describes the change of a field in a changed event
+type EventChange struct {
+	// The name of the field which changed
+	Field string `json:"field,omitempty"`
+
+	// How the field changed: changed, added or removed
+	Action string `json:"action,omitempty"`
+
+	// The new value of the field, for a changed action
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+
+	// The value added to a list field, for an added action
+	AddedValue json.RawMessage `json:"added_value,omitempty"`
+
+	// The value removed from a list field, for a removed action
+	RemovedValue json.RawMessage `json:"removed_value,omitempty"`
+}
+
+// Event is a change to a resource delivered to a webhook
+type Event struct {
+	// The user who made the change, which is nil for changes made by Asana
+	User *User `json:"user,omitempty"`
+
+	// The time at which the change was made
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// The action of the event: added, removed, changed, deleted or undeleted
+	Action string `json:"action,omitempty"`
+
+	// The resource which changed
+	Resource *EventResource `json:"resource,omitempty"`
+
+	// For added and removed events, the resource the resource was added to
+	// or removed from, e.g. the project of a task
+	Parent *EventResource `json:"parent,omitempty"`
+
+	// For changed events, the field which changed
+	Change *EventChange `json:"change,omitempty"`
+}
+
+// Fingerprint identifies an event independently of its delivery. Asana may
+// deliver the same event more than once, and a redelivered event has the
+// same fingerprint. It combines the resource, time and action of the event
+// with its parent and changed field, which distinguish separate events made
+// in the same instant, such as adding a task to two projects.
+func (e *Event) Fingerprint() string {
+	createdAt := ""
+	if e.CreatedAt != nil {
+		createdAt = e.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	parts := []string{e.Resource.gid(), createdAt, e.Action, e.Parent.gid()}
+	if e.Change != nil {
+		parts = append(parts, e.Change.Field, e.Change.Action)
+	}
+	return strings.Join(parts, "|")
+}
+
+func (r *EventResource) gid() string {
+	if r == nil {
+		return ""
+	}
+	return r.ID
+}
+
+// EventSet records the fingerprints of events which have been handled.
+// Implementations backed by a shared store can deduplicate events across
+// several receivers.
+type EventSet interface {
+	// Add records a fingerprint, and returns false if it was already
+	// recorded
+	Add(fingerprint string) bool
+}
+
+// memoryEventSet is an EventSet held in memory
+type memoryEventSet struct {
+	lock sync.Mutex
+	seen map[string]bool
+}
+
+// NewEventSet returns an EventSet held in memory, which is safe for
+// concurrent use. It is never pruned, so it suits short-lived receivers; a
+// long-running receiver should use a store which expires old fingerprints.
+func NewEventSet() EventSet {
+	return &memoryEventSet{seen: make(map[string]bool)}
+}
+
+func (s *memoryEventSet) Add(fingerprint string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.seen[fingerprint] {
+		return false
+	}
+	s.seen[fingerprint] = true
+	return true
+}
+
+// DedupeEvents returns the events whose fingerprints have not been seen
+// before, in order, and records them in seen. This includes events repeated
+// within the list.
+//
+// A receiver should first check the X-Hook-Signature of a delivery with
+// VerifyWebhookSignature, then parse it with ParseWebhookEvents and
+// deduplicate the events before handling them.
+func DedupeEvents(events []*Event, seen EventSet) []*Event {
+	var result []*Event
+	for _, event := range events {
+		if seen.Add(event.Fingerprint()) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// ParseWebhookEvents parses the body of a webhook delivery
+func ParseWebhookEvents(body []byte) ([]*Event, error) {
+	var delivery struct {
+		Events []*Event `json:"events"`
+	}
+	if err := json.Unmarshal(body, &delivery); err != nil {
+		return nil, errors.Wrap(err, "Unable to parse webhook events")
+	}
+	return delivery.Events, nil
+}
+
+// VerifyWebhookSignature checks the X-Hook-Signature header of a webhook
+// delivery, which is the hex encoded HMAC-SHA256 of the body keyed with the
+// secret sent in the X-Hook-Secret header when the webhook was created
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,51 @@
+package asana
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+const webhookDelivery = `{"events": [
+	{"action": "added", "created_at": "2024-03-01T10:00:00.000Z", "resource": {"gid": "1", "resource_type": "task"}, "parent": {"gid": "10", "resource_type": "project"}},
+	{"action": "added", "created_at": "2024-03-01T10:00:00.000Z", "resource": {"gid": "1", "resource_type": "task"}, "parent": {"gid": "11", "resource_type": "project"}},
+	{"action": "changed", "created_at": "2024-03-01T10:00:01.000Z", "resource": {"gid": "1", "resource_type": "task"}, "change": {"field": "name", "action": "changed"}},
+	{"action": "changed", "created_at": "2024-03-01T10:00:01.000Z", "resource": {"gid": "1", "resource_type": "task"}, "change": {"field": "name", "action": "changed"}}
+]}`
+
+func TestDedupeEvents(t *testing.T) {
+	events, err := ParseWebhookEvents([]byte(webhookDelivery))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := NewEventSet()
+	unique := DedupeEvents(events, seen)
+	if len(unique) != 3 {
+		t.Errorf("Expected the repeated change to be removed, but saw %d events", len(unique))
+	}
+
+	// A redelivery of the same events is removed entirely
+	redelivered, _ := ParseWebhookEvents([]byte(webhookDelivery))
+	if again := DedupeEvents(redelivered, seen); len(again) != 0 {
+		t.Errorf("Expected no events from a redelivery, but saw %d", len(again))
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(webhookDelivery)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	if !VerifyWebhookSignature("secret", body, signature) {
+		t.Error("Expected a valid signature to be accepted")
+	}
+	if VerifyWebhookSignature("other", body, signature) {
+		t.Error("Expected a signature with another secret to be rejected")
+	}
+	if VerifyWebhookSignature("secret", body, "not hex") {
+		t.Error("Expected a malformed signature to be rejected")
+	}
+}
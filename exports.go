@@ -0,0 +1,164 @@
+package asana
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Organization export states for OrganizationExport.State
+const (
+	ExportStatePending  = "pending"
+	ExportStateStarted  = "started"
+	ExportStateFinished = "finished"
+	ExportStateError    = "error"
+)
+
+// OrganizationExport is a request to export the complete data of an
+// organization in JSON format. Exports are only available to service
+// accounts of Enterprise organizations.
+type OrganizationExport struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Read-only. The current state of the export, see the ExportState
+	// constants.
+	State string `json:"state,omitempty"`
+
+	// Read-only. Once the export has finished, a pre-signed URL from which
+	// the export can be downloaded. Like attachment download URLs, it is
+	// only valid for a limited time.
+	DownloadURL string `json:"download_url,omitempty"`
+
+	// Read-only. The organization being exported.
+	Organization *Workspace `json:"organization,omitempty"`
+}
+
+// CreateExport starts an export of this organization. The export runs in
+// the background: Fetch it until its State is finished, then Download it.
+func (w *Workspace) CreateExport(client *Client) (*OrganizationExport, error) {
+	client.info("Creating export of organization %q", w.Name)
+
+	m := map[string]interface{}{
+		"organization": w.ID,
+	}
+
+	result := &OrganizationExport{}
+	err := client.post("/organization_exports", m, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Create organization export")
+	}
+	return result, nil
+}
+
+// Fetch loads the current state of this OrganizationExport
+func (e *OrganizationExport) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading organization export %s", e.ID)
+
+	_, err := client.get(fmt.Sprintf("/organization_exports/%s", e.ID), nil, e, opts...)
+	return err
+}
+
+// Download opens the gzip compressed content of a finished export for
+// reading, see DownloadContext
+func (e *OrganizationExport) Download(client *Client) (io.ReadCloser, error) {
+	return e.DownloadContext(context.Background(), client)
+}
+
+// DownloadContext opens the gzip compressed content of a finished export
+// for reading. The export is fetched first if it has no download URL. The
+// caller must close the returned reader. The download stops with an error
+// once the context is done.
+//
+// The download URL is pre-signed, so it is requested without the client's
+// credentials, see Client.DownloadClient.
+func (e *OrganizationExport) DownloadContext(ctx context.Context, client *Client) (io.ReadCloser, error) {
+	if e.DownloadURL == "" {
+		if err := e.Fetch(client); err != nil {
+			return nil, err
+		}
+		if e.DownloadURL == "" {
+			return nil, errors.Errorf("Organization export %s is %s and cannot be downloaded yet", e.ID, e.State)
+		}
+	}
+
+	client.trace("Downloading organization export %s", e.ID)
+	return client.downloadPresigned(ctx, e.DownloadURL, "Download organization export", nil)
+}
+
+// ReadExportEntities decompresses a downloaded export and calls fn with each
+// JSON object it contains, without loading the whole export into memory.
+// The objects may be given either as a stream of JSON values, one per line,
+// or as a single JSON array.
+//
+// Reading stops at the first error returned by fn, which is returned.
+func ReadExportEntities(r io.Reader, fn func(entity json.RawMessage) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "Read organization export")
+	}
+	defer gz.Close()
+
+	buffered := bufio.NewReader(gz)
+	decoder := json.NewDecoder(buffered)
+
+	// Step into a top-level array
+	if first, err := peekNonSpace(buffered); err == nil && first == '[' {
+		if _, err := decoder.Token(); err != nil {
+			return errors.Wrap(err, "Read organization export")
+		}
+	}
+
+	for decoder.More() {
+		var entity json.RawMessage
+		if err := decoder.Decode(&entity); err != nil {
+			return errors.Wrap(err, "Read organization export")
+		}
+		if err := fn(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// peekNonSpace returns the first byte which is not whitespace without
+// consuming it
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			r.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
+
+// GID returns the globally unique ID of this OrganizationExport
+func (e *OrganizationExport) GID() string {
+	return e.ID
+}
+
+// Type returns the resource type of this OrganizationExport
+func (e *OrganizationExport) Type() string {
+	if e.ResourceType != "" {
+		return e.ResourceType
+	}
+	return "organization_export"
+}
@@ -0,0 +1,47 @@
+package asana
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+)
+
+func gzipped(t *testing.T, s string) *bytes.Buffer {
+	t.Helper()
+
+	buffer := &bytes.Buffer{}
+	w := gzip.NewWriter(buffer)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer
+}
+
+func TestReadExportEntities(t *testing.T) {
+	exports := []string{
+		"{\"gid\":\"1\",\"resource_type\":\"task\"}\n{\"gid\":\"2\",\"resource_type\":\"project\"}\n",
+		` [{"gid":"1","resource_type":"task"}, {"gid":"2","resource_type":"project"}]`,
+	}
+
+	for _, export := range exports {
+		var ids []string
+		err := ReadExportEntities(gzipped(t, export), func(entity json.RawMessage) error {
+			var task Task
+			if err := json.Unmarshal(entity, &task); err != nil {
+				return err
+			}
+			ids = append(ids, task.ID)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+			t.Errorf("Expected entities 1 and 2, but saw %v", ids)
+		}
+	}
+}
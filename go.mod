@@ -3,7 +3,6 @@ module github.com/andoma-go/asana-go
 go 1.21
 
 require (
-	dario.cat/mergo v1.0.0
 	github.com/google/go-querystring v1.1.0
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/pkg/errors v0.9.1
@@ -0,0 +1,197 @@
+package asana
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Progress sources for GoalMetric.ProgressSource
+const (
+	ProgressSourceManual                     = "manual"
+	ProgressSourceSubgoalProgress            = "subgoal_progress"
+	ProgressSourceProjectTaskCompletion      = "project_task_completion"
+	ProgressSourceProjectMilestoneCompletion = "project_milestone_completion"
+	ProgressSourceTaskCompletion             = "task_completion"
+	ProgressSourceExternal                   = "external"
+)
+
+// GoalMetric describes how the progress of a goal is measured
+type GoalMetric struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// The type of the metric. Currently always number.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// The number of places after the decimal to round to, between 0 and 6.
+	Precision *int `json:"precision,omitempty"`
+
+	// The unit of the metric: none, currency or percentage.
+	Unit string `json:"unit,omitempty"`
+
+	// ISO 4217 currency code to format the metric, when the unit is currency.
+	CurrencyCode string `json:"currency_code,omitempty"`
+
+	// The value of the metric when the goal was created.
+	InitialNumberValue *float64 `json:"initial_number_value,omitempty"`
+
+	// The value of the metric at which the goal is achieved.
+	TargetNumberValue *float64 `json:"target_number_value,omitempty"`
+
+	// The current value of the metric.
+	CurrentNumberValue *float64 `json:"current_number_value,omitempty"`
+
+	// Read-only. The current value of the metric formatted for display.
+	CurrentDisplayValue string `json:"current_display_value,omitempty"`
+
+	// How progress is updated: manual, subgoal_progress,
+	// project_task_completion, project_milestone_completion,
+	// task_completion or external. Only manual metrics can be updated with
+	// Goal.UpdateMetric.
+	ProgressSource string `json:"progress_source,omitempty"`
+}
+
+// Goal is an objective tracked in a workspace or team. Goals require a
+// premium plan, see IsPremiumRequired.
+type Goal struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// The name of the goal.
+	Name string `json:"name,omitempty"`
+
+	// Free-form textual information associated with the goal.
+	Notes string `json:"notes,omitempty"`
+
+	// The notes of the goal with formatting as HTML.
+	HTMLNotes string `json:"html_notes,omitempty"`
+
+	// The day on which this goal is due.
+	DueOn *Date `json:"due_on,omitempty"`
+
+	// The day on which work for this goal begins.
+	StartOn *Date `json:"start_on,omitempty"`
+
+	// The current status of the goal, e.g. green, yellow or red.
+	Status string `json:"status,omitempty"`
+
+	// True if the goal belongs to the workspace rather than a team.
+	IsWorkspaceLevel bool `json:"is_workspace_level,omitempty"`
+
+	// The owner of the goal.
+	Owner *User `json:"owner,omitempty"`
+
+	// The team the goal belongs to, if it is not workspace level.
+	Team *Team `json:"team,omitempty"`
+
+	// The workspace the goal belongs to.
+	Workspace *Workspace `json:"workspace,omitempty"`
+
+	// The metric used to measure the progress of the goal, if any.
+	Metric *GoalMetric `json:"metric,omitempty"`
+
+	// The planning cycle the goal belongs to, such as a quarter or fiscal
+	// year.
+	TimePeriod *TimePeriod `json:"time_period,omitempty"`
+}
+
+// CreateGoalRequest represents a request to create a new goal
+type CreateGoalRequest struct {
+	Name      string `json:"name"`
+	Notes     string `json:"notes,omitempty"`
+	HTMLNotes string `json:"html_notes,omitempty"`
+	DueOn     *Date  `json:"due_on,omitempty"`
+	StartOn   *Date  `json:"start_on,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+
+	// The team the goal belongs to. Leave empty and set IsWorkspaceLevel
+	// for a goal of the whole workspace.
+	Team             string `json:"team,omitempty"`
+	IsWorkspaceLevel bool   `json:"is_workspace_level,omitempty"`
+
+	// The ID of the time period the goal belongs to, see
+	// Workspace.TimePeriods
+	TimePeriod string `json:"time_period,omitempty"`
+
+	Workspace string `json:"workspace"`
+}
+
+// Validate checks the goal before it is sent to the API
+func (r *CreateGoalRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("A goal must have a name")
+	}
+	if r.Team != "" && r.IsWorkspaceLevel {
+		return errors.New("A goal cannot belong to both a team and the workspace")
+	}
+	return nil
+}
+
+// CreateGoal adds a new goal to this workspace. Goals require a premium
+// plan, and otherwise this fails with a PremiumRequiredError.
+func (w *Workspace) CreateGoal(client *Client, goal *CreateGoalRequest) (*Goal, error) {
+	client.info("Creating goal %q in %q", goal.Name, w.Name)
+
+	goal.Workspace = w.ID
+
+	result := &Goal{}
+	err := client.post("/goals", goal, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Create goal")
+	}
+	return result, nil
+}
+
+// Fetch loads the full details for this Goal, failing with a
+// PremiumRequiredError if the workspace is not on a premium plan
+func (g *Goal) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading goal details for %q", g.Name)
+
+	_, err := client.get(fmt.Sprintf("/goals/%s", g.ID), nil, g, opts...)
+	return err
+}
+
+// UpdateMetric sets the current value of the goal's metric.
+//
+// Only metrics with a manual progress source can be updated; the values of
+// other metrics are calculated by Asana and an error is returned without
+// making the update. The metric is loaded first if it has not been. Like
+// the other goal requests, this fails with a PremiumRequiredError outside
+// premium plans.
+func (g *Goal) UpdateMetric(client *Client, currentValue float64) error {
+	client.trace("Updating metric of goal %q", g.Name)
+
+	if g.Metric == nil || g.Metric.ProgressSource == "" {
+		if err := g.Fetch(client, &Options{Fields: []string{"metric.progress_source"}}); err != nil {
+			return err
+		}
+	}
+	if g.Metric == nil {
+		return errors.Errorf("Goal %s has no metric", g.ID)
+	}
+	if g.Metric.ProgressSource != ProgressSourceManual {
+		return errors.Errorf("The metric of goal %s is updated automatically from %s", g.ID, g.Metric.ProgressSource)
+	}
+
+	m := map[string]interface{}{
+		"current_number_value": currentValue,
+	}
+	return client.post(fmt.Sprintf("/goals/%s/setMetricCurrentValue", g.ID), m, g)
+}
+
+// GID returns the globally unique ID of this Goal
+func (g *Goal) GID() string {
+	return g.ID
+}
+
+// Type returns the resource type of this Goal
+func (g *Goal) Type() string {
+	if g.ResourceType != "" {
+		return g.ResourceType
+	}
+	return "goal"
+}
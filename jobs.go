@@ -0,0 +1,151 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Job statuses
+const (
+	JobStatusNotStarted = "not_started"
+	JobStatusInProgress = "in_progress"
+	JobStatusSucceeded  = "succeeded"
+	JobStatusFailed     = "failed"
+)
+
+// Job subtypes
+const (
+	JobSubtypeDuplicateTask      = "duplicate_task"
+	JobSubtypeDuplicateProject   = "duplicate_project"
+	JobSubtypeInstantiateProject = "instantiate_project"
+)
+
+// Job represents a long-running process started by an API request, such as
+// instantiating a project template. The job can be fetched repeatedly to
+// follow its progress.
+type Job struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The subtype of this resource, describing the kind of job.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// Read-only. The current status of this job: not_started, in_progress,
+	// succeeded or failed.
+	Status string `json:"status,omitempty"`
+
+	// Read-only. The project created by the job, if any.
+	NewProject *Project `json:"new_project,omitempty"`
+
+	// Read-only. The task created by the job, if any.
+	NewTask *Task `json:"new_task,omitempty"`
+}
+
+// Fetch loads the current details for this Job
+func (j *Job) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading job details for %q", j.ID)
+
+	_, err := client.get(fmt.Sprintf("/jobs/%s", j.ID), nil, j, opts...)
+	return err
+}
+
+// minJobPollInterval is the shortest interval at which Wait polls a job, so
+// that a zero interval does not make requests in a tight loop
+const minJobPollInterval = time.Second
+
+// Wait polls the job at the given interval until it has finished, and
+// returns its result, see WaitContext. Wait does not give up on a job which
+// never finishes; use WaitContext with a deadline to bound the wait.
+func (j *Job) Wait(client *Client, interval time.Duration) (*JobResult, error) {
+	return j.WaitContext(context.Background(), client, interval)
+}
+
+// WaitContext polls the job at the given interval until it has finished, and
+// returns its result. An error is returned if the job failed. Intervals
+// shorter than a second are raised to a second.
+//
+// Polling stops once the context is cancelled or its deadline passes, and
+// the context's error is returned.
+func (j *Job) WaitContext(ctx context.Context, client *Client, interval time.Duration) (*JobResult, error) {
+	if interval < minJobPollInterval {
+		interval = minJobPollInterval
+	}
+
+	for j.Status != JobStatusSucceeded && j.Status != JobStatusFailed {
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Wrapf(ctx.Err(), "Wait for job %s", j.ID)
+		}
+
+		options := &Options{}
+		if deadline, ok := ctx.Deadline(); ok {
+			options.Timeout = time.Until(deadline)
+			if options.Timeout <= 0 {
+				return nil, errors.Wrapf(context.DeadlineExceeded, "Wait for job %s", j.ID)
+			}
+		}
+		if err := j.Fetch(client, options); err != nil {
+			return nil, err
+		}
+	}
+	return j.Result()
+}
+
+// Result returns the object created by a job which has succeeded
+func (j *Job) Result() (*JobResult, error) {
+	switch j.Status {
+	case JobStatusSucceeded:
+		return &JobResult{job: j}, nil
+	case JobStatusFailed:
+		return nil, errors.Errorf("Job %s failed", j.ID)
+	default:
+		return nil, errors.Errorf("Job %s has not finished: %s", j.ID, j.Status)
+	}
+}
+
+// JobResult is the object created by a job, which is a task or a project
+// depending on the subtype of the job
+type JobResult struct {
+	job *Job
+}
+
+// AsTask returns the task created by a duplicate_task job
+func (r *JobResult) AsTask() (*Task, bool) {
+	switch r.job.ResourceSubtype {
+	case JobSubtypeDuplicateTask, "":
+		return r.job.NewTask, r.job.NewTask != nil
+	}
+	return nil, false
+}
+
+// AsProject returns the project created by a duplicate_project or
+// instantiate_project job
+func (r *JobResult) AsProject() (*Project, bool) {
+	switch r.job.ResourceSubtype {
+	case JobSubtypeDuplicateProject, JobSubtypeInstantiateProject, "":
+		return r.job.NewProject, r.job.NewProject != nil
+	}
+	return nil, false
+}
+
+// GID returns the globally unique ID of this Job
+func (j *Job) GID() string {
+	return j.ID
+}
+
+// Type returns the resource type of this Job
+func (j *Job) Type() string {
+	if j.ResourceType != "" {
+		return j.ResourceType
+	}
+	return "job"
+}
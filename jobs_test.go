@@ -0,0 +1,38 @@
+package asana
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestJob_WaitContext_Cancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request after the context was cancelled, but saw %s", r.URL)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	job := &Job{ID: "1", Status: JobStatusInProgress}
+	if _, err := job.WaitContext(ctx, client, 0); errors.Cause(err) != context.Canceled {
+		t.Errorf("Expected the context's error, but saw %v", err)
+	}
+}
+
+func TestJob_WaitContext_Succeeded(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"gid": "1", "resource_subtype": "duplicate_task", "status": "succeeded", "new_task": {"gid": "2"}}}`))
+	})
+
+	job := &Job{ID: "1", Status: JobStatusInProgress}
+	result, err := job.WaitContext(context.Background(), client, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task, ok := result.AsTask(); !ok || task.ID != "2" {
+		t.Errorf("Expected the new task 2, but saw %v", task)
+	}
+}
@@ -10,6 +10,12 @@ func (c *Client) info(format string, args ...interface{}) {
 	}
 }
 
+// warn logs a message regardless of the verbosity, for requests which did
+// not do everything the caller asked for
+func (c *Client) warn(format string, args ...interface{}) {
+	log.Printf("Warning: "+format, args...)
+}
+
 func (c *Client) trace(format string, args ...interface{}) {
 	if len(c.Verbose) > 1 {
 		log.Printf(format, args...)
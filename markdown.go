@@ -0,0 +1,372 @@
+package asana
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	markdownHeading  = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	markdownRule     = regexp.MustCompile(`^ {0,3}([-*_])( *[-*_]){2,} *$`)
+	markdownListItem = regexp.MustCompile(`^( *)([-*+]|\d+[.)])\s+(.*)$`)
+	markdownFence    = regexp.MustCompile("^ {0,3}(```|~~~)")
+	markdownQuote    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+)
+
+// MarkdownToAsanaHTML converts common markdown into rich text markup for
+// comments, task and project notes, and project briefs.
+//
+// Headings, bold, italic, strikethrough, inline code, fenced code blocks,
+// block quotes, horizontal rules, links and nested bullet or numbered lists
+// are converted to their rich text equivalents. Headings below the second
+// level become <h2>, and images become links to the image. Line breaks
+// within a paragraph are kept, as Asana displays them. Anything else,
+// including raw HTML and tables, is escaped and appears as plain text.
+//
+// Links must use an http, https or mailto URL, otherwise an error is
+// returned.
+func MarkdownToAsanaHTML(md string) (string, error) {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	body, err := markdownBlocks(lines)
+	if err != nil {
+		return "", err
+	}
+
+	result := "<body>" + body + "</body>"
+	if err := ValidateHTMLText(result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// markdownList is an open list element while converting markdown
+type markdownList struct {
+	indent int
+	tag    string
+}
+
+// markdownBlocks converts markdown lines into rich text block elements
+func markdownBlocks(lines []string) (string, error) {
+	var out strings.Builder
+	var paragraph []string
+	var lists []markdownList
+	lastWasText := false
+
+	flushParagraph := func() error {
+		if len(paragraph) == 0 {
+			return nil
+		}
+		text, err := markdownInline(strings.Join(paragraph, "\n"))
+		if err != nil {
+			return err
+		}
+		if lastWasText {
+			out.WriteString("\n\n")
+		}
+		out.WriteString(text)
+		paragraph = nil
+		lastWasText = true
+		return nil
+	}
+
+	closeLists := func(indent int) {
+		for len(lists) > 0 && lists[len(lists)-1].indent > indent {
+			out.WriteString("</li></" + lists[len(lists)-1].tag + ">")
+			lists = lists[:len(lists)-1]
+		}
+	}
+
+	// block starts a non-text block element, ending any open paragraph or
+	// list
+	block := func() error {
+		if err := flushParagraph(); err != nil {
+			return err
+		}
+		closeLists(-1)
+		lastWasText = false
+		return nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			if err := flushParagraph(); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		if m := markdownFence.FindStringSubmatch(line); m != nil {
+			if err := block(); err != nil {
+				return "", err
+			}
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre>" + richTextEscaper.Replace(strings.Join(code, "\n")) + "</pre>")
+			continue
+		}
+
+		if m := markdownHeading.FindStringSubmatch(line); m != nil {
+			if err := block(); err != nil {
+				return "", err
+			}
+			tag := "h1"
+			if len(m[1]) > 1 {
+				tag = "h2"
+			}
+			text, err := markdownInline(m[2])
+			if err != nil {
+				return "", err
+			}
+			out.WriteString("<" + tag + ">" + text + "</" + tag + ">")
+			continue
+		}
+
+		if markdownRule.MatchString(line) {
+			if err := block(); err != nil {
+				return "", err
+			}
+			out.WriteString("<hr/>")
+			continue
+		}
+
+		if markdownQuote.MatchString(line) {
+			if err := block(); err != nil {
+				return "", err
+			}
+			var quoted []string
+			for ; i < len(lines); i++ {
+				m := markdownQuote.FindStringSubmatch(lines[i])
+				if m == nil {
+					break
+				}
+				quoted = append(quoted, m[1])
+			}
+			i--
+			text, err := markdownBlocks(quoted)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString("<blockquote>" + text + "</blockquote>")
+			continue
+		}
+
+		if m := markdownListItem.FindStringSubmatch(line); m != nil {
+			if len(lists) == 0 {
+				if err := block(); err != nil {
+					return "", err
+				}
+			} else if err := flushParagraph(); err != nil {
+				return "", err
+			}
+
+			indent := len(m[1])
+			tag := "ul"
+			if unicode.IsDigit(rune(m[2][0])) {
+				tag = "ol"
+			}
+
+			closeLists(indent)
+			switch {
+			case len(lists) > 0 && lists[len(lists)-1].indent == indent && lists[len(lists)-1].tag == tag:
+				out.WriteString("</li><li>")
+			case len(lists) > 0 && lists[len(lists)-1].indent == indent:
+				out.WriteString("</li></" + lists[len(lists)-1].tag + "><" + tag + "><li>")
+				lists[len(lists)-1].tag = tag
+			default:
+				out.WriteString("<" + tag + "><li>")
+				lists = append(lists, markdownList{indent: indent, tag: tag})
+			}
+
+			text, err := markdownInline(m[3])
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(text)
+			lastWasText = false
+			continue
+		}
+
+		// Indented lines continue the current list item
+		if len(lists) > 0 && len(paragraph) == 0 && strings.HasPrefix(line, " ") {
+			text, err := markdownInline(strings.TrimSpace(line))
+			if err != nil {
+				return "", err
+			}
+			out.WriteString("\n" + text)
+			continue
+		}
+
+		if len(lists) > 0 {
+			closeLists(-1)
+			lastWasText = false
+		}
+		paragraph = append(paragraph, strings.TrimSpace(line))
+	}
+
+	if err := flushParagraph(); err != nil {
+		return "", err
+	}
+	closeLists(-1)
+	return out.String(), nil
+}
+
+// markdownInline converts inline markdown within a block of text
+func markdownInline(s string) (string, error) {
+	var out strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		rest := s[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && unicode.IsPunct(rune(s[i+1])):
+			out.WriteString(richTextEscaper.Replace(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				out.WriteString("<code>" + richTextEscaper.Replace(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if n, err := markdownSpan(&out, s, i, rest[:2], "strong"); err != nil || n > 0 {
+				i += n
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if n, err := markdownSpan(&out, s, i, "~~", "s"); err != nil || n > 0 {
+				i += n
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+
+		case c == '*' || (c == '_' && (i == 0 || !isWordByte(s[i-1]))):
+			if n, err := markdownSpan(&out, s, i, rest[:1], "em"); err != nil || n > 0 {
+				i += n
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+
+		case c == '[' || strings.HasPrefix(rest, "!["):
+			if n, err := markdownLink(&out, s, i); err != nil || n > 0 {
+				i += n
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(rest, '>'); end > 0 {
+				if target := rest[1:end]; isMarkdownURL(target) {
+					out.WriteString(`<a href="` + richTextAttrEscaper.Replace(target) + `">` + richTextEscaper.Replace(target) + "</a>")
+					i += end + 1
+					continue
+				}
+			}
+		}
+
+		out.WriteString(richTextEscaper.Replace(s[i : i+1]))
+		i++
+	}
+
+	return out.String(), nil
+}
+
+// markdownSpan writes an emphasis span starting at s[i] if the delimiter is
+// closed later in the text, and returns the number of bytes consumed
+func markdownSpan(out *strings.Builder, s string, i int, delim, tag string) (int, error) {
+	start := i + len(delim)
+	if start >= len(s) || s[start] == ' ' {
+		return 0, nil
+	}
+
+	end := strings.Index(s[start:], delim)
+	if end <= 0 || s[start+end-1] == ' ' {
+		return 0, nil
+	}
+	if delim == "_" && start+end+1 < len(s) && isWordByte(s[start+end+1]) {
+		return 0, nil
+	}
+
+	inner, err := markdownInline(s[start : start+end])
+	if err != nil {
+		return 0, err
+	}
+	out.WriteString("<" + tag + ">" + inner + "</" + tag + ">")
+	return end + 2*len(delim), nil
+}
+
+// markdownLink writes a link or image starting at s[i] if it is well formed,
+// and returns the number of bytes consumed
+func markdownLink(out *strings.Builder, s string, i int) (int, error) {
+	start := i + 1
+	if s[i] == '!' {
+		start++
+	}
+
+	textEnd := strings.Index(s[start:], "](")
+	if textEnd < 0 {
+		return 0, nil
+	}
+	urlStart := start + textEnd + 2
+	urlEnd := strings.IndexByte(s[urlStart:], ')')
+	if urlEnd < 0 {
+		return 0, nil
+	}
+
+	target := strings.TrimSpace(s[urlStart : urlStart+urlEnd])
+	if !isMarkdownURL(target) {
+		return 0, errors.Errorf("Unsupported link URL %q in markdown", target)
+	}
+
+	text, err := markdownInline(s[start : start+textEnd])
+	if err != nil {
+		return 0, err
+	}
+	if text == "" {
+		text = richTextEscaper.Replace(target)
+	}
+	out.WriteString(`<a href="` + richTextAttrEscaper.Replace(target) + `">` + text + "</a>")
+	return urlStart + urlEnd + 1 - i, nil
+}
+
+// isMarkdownURL reports whether a link target is an absolute URL with a
+// scheme which can be linked from rich text
+func isMarkdownURL(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "mailto":
+		return u.Opaque != ""
+	}
+	return false
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -0,0 +1,37 @@
+package asana
+
+import (
+	"testing"
+)
+
+func TestMarkdownToAsanaHTML(t *testing.T) {
+	tests := map[string]string{
+		"Plain & simple":                                    "<body>Plain &amp; simple</body>",
+		"**bold** and *italic* and ~~no~~":                  "<body><strong>bold</strong> and <em>italic</em> and <s>no</s></body>",
+		"snake_case_name and _em_":                          "<body>snake_case_name and <em>em</em></body>",
+		"Run `a < b` now":                                   "<body>Run <code>a &lt; b</code> now</body>",
+		"See [the **spec**](https://example.com/a?b=1&c=2)": `<body>See <a href="https://example.com/a?b=1&amp;c=2">the <strong>spec</strong></a></body>`,
+		"# Title\n### Sub":                                  `<body><h1>Title</h1><h2>Sub</h2></body>`,
+		"One\nTwo\n\nThree":                                 "<body>One\nTwo\n\nThree</body>",
+		"- a\n- b\n  - c\n- d":                              "<body><ul><li>a</li><li>b<ul><li>c</li></ul></li><li>d</li></ul></body>",
+		"1. first\n2. second\n\nAfter":                      "<body><ol><li>first</li><li>second</li></ol>After</body>",
+		"```\nif a < b {\n}\n```":                           "<body><pre>if a &lt; b {\n}</pre></body>",
+		"> quoted\n> text\n\n---":                           "<body><blockquote>quoted\ntext</blockquote><hr/></body>",
+		"<script>x</script>":                                "<body>&lt;script&gt;x&lt;/script&gt;</body>",
+		"![logo](https://example.com/l.png)":                `<body><a href="https://example.com/l.png">logo</a></body>`,
+	}
+	for md, expected := range tests {
+		text, err := MarkdownToAsanaHTML(md)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", md, err)
+			continue
+		}
+		if text != expected {
+			t.Errorf("Expected %q to convert to %q, but saw %q", md, expected, text)
+		}
+	}
+
+	if _, err := MarkdownToAsanaHTML("[click](javascript:alert(1))"); err == nil {
+		t.Error("Expected an error for a javascript link")
+	}
+}
@@ -0,0 +1,75 @@
+package asana
+
+import (
+	"encoding/json"
+)
+
+// Optional is a field of an update request which distinguishes between
+// leaving a value unchanged, setting it to any value including the zero
+// value, and clearing it with null. An omitempty field cannot make these
+// distinctions: it drops zero values, and cannot send null at all.
+//
+// Optional fields are declared as pointers with omitempty:
+//
+//   - a nil *Optional leaves the value unchanged
+//   - SetValue(value) sends the value, even if it is the zero value
+//   - NullValue[T]() sends null, which clears the value
+type Optional[T any] struct {
+	value T
+	null  bool
+}
+
+// SetValue returns an Optional which sends the given value
+func SetValue[T any](value T) *Optional[T] {
+	return &Optional[T]{value: value}
+}
+
+// NullValue returns an Optional which sends null
+func NullValue[T any]() *Optional[T] {
+	return &Optional[T]{null: true}
+}
+
+// Value returns the value of the Optional, and false if it is null or unset
+func (o *Optional[T]) Value() (T, bool) {
+	if o == nil || o.null {
+		var zero T
+		return zero, false
+	}
+	return o.value, true
+}
+
+// optionalOrNull returns an Optional which sends the value pointed to, or
+// null if the pointer is nil
+func optionalOrNull[T any](value *T) *Optional[T] {
+	if value == nil {
+		return NullValue[T]()
+	}
+	return SetValue(*value)
+}
+
+// IsNull returns true if the Optional sends null
+func (o *Optional[T]) IsNull() bool {
+	return o != nil && o.null
+}
+
+// MarshalJSON implements the json.Marshaller interface
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.null {
+		return []byte("null"), nil
+	}
+	// Marshal a pointer, so that marshallers with pointer receivers such as
+	// Date's are used
+	return json.Marshal(&o.value)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		var zero T
+		o.value, o.null = zero, true
+		return nil
+	}
+
+	o.null = false
+	return json.Unmarshal(data, &o.value)
+}
@@ -0,0 +1,64 @@
+package asana
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestOptional_MarshalJSON(t *testing.T) {
+	type request struct {
+		Assignee *Optional[string] `json:"assignee,omitempty"`
+	}
+
+	tests := []struct {
+		request  *request
+		expected string
+	}{
+		{&request{}, `{}`},
+		{&request{Assignee: SetValue("123")}, `{"assignee":"123"}`},
+		{&request{Assignee: SetValue("")}, `{"assignee":""}`},
+		{&request{Assignee: NullValue[string]()}, `{"assignee":null}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("Expected %s, but saw %s", test.expected, data)
+		}
+	}
+}
+
+func TestOptional_Value(t *testing.T) {
+	var unset *Optional[int]
+	if _, ok := unset.Value(); ok || unset.IsNull() {
+		t.Error("Expected an unset Optional to have no value and not be null")
+	}
+
+	if v, ok := SetValue(0).Value(); !ok || v != 0 {
+		t.Errorf("Expected a set zero value, but saw %v, %v", v, ok)
+	}
+
+	if _, ok := NullValue[int]().Value(); ok || !NullValue[int]().IsNull() {
+		t.Error("Expected a null Optional to have no value")
+	}
+}
+
+func TestOptional_MarshalJSON_PointerMarshaller(t *testing.T) {
+	due := Date(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC))
+
+	data, err := json.Marshal(optionalOrNull(&due))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"2024-03-08"` {
+		t.Errorf("Expected the date to be marshalled with Date.MarshalJSON, but saw %s", data)
+	}
+
+	if data, err := json.Marshal(optionalOrNull[Date](nil)); err != nil || string(data) != "null" {
+		t.Errorf("Expected a nil date to be marshalled as null, but saw %s, %v", data, err)
+	}
+}
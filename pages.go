@@ -0,0 +1,50 @@
+package asana
+
+// listAll repeatedly pages through all results of a list function. The first
+// page is requested with the given page size, and each following page from
+// the path or URI in the previous NextPage, so the server's page size and
+// any filters in the original query are kept. Options given in opts take
+// precedence over the page size of the first page.
+func listAll[T any](client *Client, pageSize int, list func(opts ...*Options) ([]T, *NextPage, error), opts ...*Options) ([]T, error) {
+	var result []T
+	err := eachPage(client, pageSize, list, func(items []T) (bool, error) {
+		result = append(result, items...)
+		return true, nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// eachPage pages through the results of a list function like listAll, but
+// calls fn with each page instead of collecting the results. Paging stops
+// when fn returns false or an error, and the error is returned.
+func eachPage[T any](client *Client, pageSize int, list func(opts ...*Options) ([]T, *NextPage, error), fn func(items []T) (bool, error), opts ...*Options) error {
+	page := &Options{Limit: pageSize}
+	items, nextPage, err := list(append([]*Options{page}, opts...)...)
+	if err != nil {
+		return err
+	}
+
+	for {
+		more, err := fn(items)
+		if err != nil || !more || nextPage == nil {
+			return err
+		}
+
+		items = nil
+		if client.nextPagePath(nextPage) != "" {
+			nextPage, err = client.getNextPage(nextPage, &items, opts...)
+		} else {
+			page := &Options{
+				Limit:  pageSize,
+				Offset: nextPage.Offset,
+			}
+			items, nextPage, err = list(append([]*Options{page}, opts...)...)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package asana
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListAll_FollowsNextPagePath(t *testing.T) {
+	var queries []string
+	var baseURL string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		switch r.URL.Query().Get("offset") {
+		case "":
+			// The server chooses a smaller page size than was requested
+			w.Write([]byte(`{
+				"data": [{"gid": "1"}, {"gid": "2"}],
+				"next_page": {"offset": "b", "path": "/workspaces?filter=x&limit=2&offset=b"}
+			}`))
+		case "b":
+			fmt.Fprintf(w, `{
+				"data": [{"gid": "3"}, {"gid": "4"}],
+				"next_page": {"offset": "c", "uri": "%s/workspaces?filter=x&limit=2&offset=c"}
+			}`, baseURL)
+		case "c":
+			w.Write([]byte(`{"data": [{"gid": "5"}], "next_page": null}`))
+		default:
+			t.Errorf("Unexpected request for %s", r.URL)
+		}
+	})
+	baseURL = client.BaseURL.String()
+
+	workspaces, err := client.AllWorkspaces(&Options{Fields: []string{"name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, workspace := range workspaces {
+		ids = append(ids, workspace.ID)
+	}
+	if strings.Join(ids, ",") != "1,2,3,4,5" {
+		t.Errorf("Expected all five workspaces in order, but saw %v", ids)
+	}
+
+	if len(queries) != 3 {
+		t.Fatalf("Expected three requests, but saw %v", queries)
+	}
+	if !strings.Contains(queries[0], "limit=100") {
+		t.Errorf("Expected the first page to request the default page size, but saw %s", queries[0])
+	}
+	for _, query := range queries[1:] {
+		if !strings.Contains(query, "filter=x") || !strings.Contains(query, "limit=2") {
+			t.Errorf("Expected the next page query to be kept, but saw %s", query)
+		}
+		if !strings.Contains(query, "opt_fields=name") {
+			t.Errorf("Expected options to be added to the next page, but saw %s", query)
+		}
+	}
+}
+
+func TestListAll_FallsBackToOffset(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "" {
+			w.Write([]byte(`{"data": [{"gid": "1"}], "next_page": {"offset": "b"}}`))
+			return
+		}
+		w.Write([]byte(`{"data": [{"gid": "2"}]}`))
+	})
+
+	workspaces, err := client.AllWorkspaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workspaces) != 2 {
+		t.Errorf("Expected two workspaces, but saw %d", len(workspaces))
+	}
+}
+
+func TestCount_FollowsNextPagePath(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{
+				"data": [{"gid": "1"}, {"gid": "2"}],
+				"next_page": {"offset": "b", "path": "/projects/1/tasks?limit=2&cursor=b"}
+			}`))
+			return
+		}
+		w.Write([]byte(`{"data": [{"gid": "3"}], "next_page": null}`))
+	})
+
+	count, err := (&Project{ID: "1"}).TaskCount(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 tasks, but saw %d", count)
+	}
+	if len(paths) != 2 || !strings.Contains(paths[1], "cursor=b") || strings.Contains(paths[1], "offset=") {
+		t.Errorf("Expected the second page to be loaded from the next page path, but saw %v", paths)
+	}
+}
@@ -1,11 +1,76 @@
 package asana
 
+import "fmt"
+
+// Portfolio is a collection of projects that can be monitored together.
+// Portfolios require a business or enterprise plan, and requests for them
+// in other workspaces return an error satisfying IsPremiumRequired.
 type Portfolio struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The name of the object.
+	Name string `json:"name,omitempty"`
+
+	// Color of the object.
+	Color string `json:"color,omitempty"`
+
+	// Read-only. The workspace or organization this object is associated
+	// with.
+	Workspace *Workspace `json:"workspace,omitempty"`
+
+	// Read-only. The current owner of the portfolio, may be null.
+	Owner *User `json:"owner,omitempty"`
+}
+
+func (p *Portfolio) GetID() string {
+	return p.ID
+}
+
+// Fetch loads the full details for this Portfolio. This fails with a
+// PremiumRequiredError if the workspace is not on a business or enterprise
+// plan.
+func (p *Portfolio) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading portfolio details for %q", p.Name)
+
+	_, err := client.get(fmt.Sprintf("/portfolios/%s", p.ID), nil, p, opts...)
+	return err
+}
+
+// Items returns a list of the items in this portfolio, which are usually
+// projects. Some fields, such as the project memberships, are only returned
+// when requested with the Fields option.
+//
+// The API does not provide the reverse lookup of the portfolios containing a
+// given project. To find them, list the items of each candidate portfolio.
+//
+// Portfolios require a business or enterprise plan, and otherwise this
+// fails with a PremiumRequiredError.
+func (p *Portfolio) Items(client *Client, opts ...*Options) ([]*Project, *NextPage, error) {
+	client.trace("Listing items in portfolio %q", p.Name)
+
+	var result []*Project
+
+	// Make the request
+	nextPage, err := client.get(fmt.Sprintf("/portfolios/%s/items", p.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// AllItems repeatedly pages through all items in this portfolio, and fails
+// with a PremiumRequiredError, like Items, outside business and enterprise
+// plans
+func (p *Portfolio) AllItems(client *Client, opts ...*Options) ([]*Project, error) {
+	return listAll(client, 100, func(opts ...*Options) ([]*Project, *NextPage, error) {
+		return p.Items(client, opts...)
+	}, opts...)
 }
 
-// Projects returns a list of projects in this workspace
+// Portfolios returns a list of the portfolios owned by the current user in
+// this workspace. This requires a business or enterprise plan, and
+// otherwise fails with a PremiumRequiredError.
 func (w *Workspace) Portfolios(client *Client, options ...*Options) ([]*Portfolio, *NextPage, error) {
 	client.trace("Listing portfolios in %q", w.Name)
 
@@ -20,3 +85,16 @@ func (w *Workspace) Portfolios(client *Client, options ...*Options) ([]*Portfoli
 	nextPage, err := client.get("/portfolios", nil, &result, append(options, o)...)
 	return result, nextPage, err
 }
+
+// GID returns the globally unique ID of this Portfolio
+func (p *Portfolio) GID() string {
+	return p.ID
+}
+
+// Type returns the resource type of this Portfolio
+func (p *Portfolio) Type() string {
+	if p.ResourceType != "" {
+		return p.ResourceType
+	}
+	return "portfolio"
+}
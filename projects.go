@@ -3,6 +3,8 @@ package asana
 import (
 	"fmt"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // ProjectStatus is a description of the project’s status containing a color
@@ -42,8 +44,9 @@ type ProjectBase struct {
 	// light-teal, light-yellow, light-orange, light-purple, light-warm-gray.
 	Color string `json:"color,omitempty"`
 
-	// A description of the project’s status containing a color (must be
-	// either null or one of: green, yellow, red) and a short description.
+	// Read-only. A description of the project’s status containing a color
+	// (must be either null or one of: green, yellow, red) and a short
+	// description. It is not sent when creating or updating a project.
 	CurrentStatus *ProjectStatus `json:"current_status,omitempty"`
 
 	// The layout (board or list view) of the project.
@@ -53,12 +56,16 @@ type ProjectBase struct {
 	// YYYY-MM-DD.
 	DueOn *Date `json:"due_on,omitempty"`
 
-	// The notes of the text with formatting as HTML.
+	// Opt In. The notes of the project with formatting as HTML. Only one of
+	// Notes and HTMLNotes may be given when creating or updating a project.
 	HTMLNotes string `json:"html_notes,omitempty"`
 
+	// The icon shown for the project, e.g. list, board, timeline, calendar,
+	// rocket, briefcase or target.
 	Icon string `json:"icon,omitempty"`
 
-	// Opt In. Determines if the project is a template.
+	// Read-only. Opt In. Determines if the project is a template. It is not
+	// sent when creating or updating a project.
 	IsTemplate *bool `json:"is_template,omitempty"`
 
 	// Read-only. The name of the object.
@@ -115,11 +122,28 @@ type Project struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The subtype of this project. The value is kept as returned
+	// by the API, including subtypes added after this package was written.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
 	ProjectBase
 
 	// Read-only. The time at which this object was created.
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 
+	// Read-only. Opt In. Who can see the project: public_to_workspace,
+	// private_to_team or private.
+	PrivacySetting string `json:"privacy_setting,omitempty"`
+
+	// Read-only. Opt In. True if the project is marked complete.
+	Completed *bool `json:"completed,omitempty"`
+
+	// Read-only. Opt In. The time at which the project was marked complete.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
 	// Read-only. Array of Custom Field Settings (in compact form).
 	CustomFieldSettings []*CustomFieldSetting `json:"custom_field_settings,omitempty"`
 
@@ -159,6 +183,53 @@ type Project struct {
 	// Create-only. The team that this project is shared with. This field only
 	// exists for projects in organizations.
 	Team *Team `json:"team,omitempty"`
+
+	// Read-only. Opt In. A url that points directly to the object within Asana.
+	Permalink string `json:"permalink_url,omitempty"`
+
+	// Read-only. Opt In. The access level of the authorized user on this
+	// project: admin, editor, commenter or viewer.
+	CurrentUserAccessLevel string `json:"current_user_access_level,omitempty"`
+
+	// Read-only. Opt In. The project brief associated with this project.
+	ProjectBrief *ProjectBrief `json:"project_brief,omitempty"`
+}
+
+// clearReadOnly drops the read-only fields, which cannot be written in create
+// and update requests
+func (p *ProjectBase) clearReadOnly() {
+	p.CurrentStatus = nil
+	p.IsTemplate = nil
+}
+
+// validateNotes checks that at most one of the plain text and rich text
+// notes is given, and that rich text notes are accepted by the API
+func (p *ProjectBase) validateNotes() error {
+	if p.HTMLNotes == "" {
+		return nil
+	}
+	if p.Notes != "" {
+		return errors.New("Only one of notes and html_notes may be specified")
+	}
+	return errors.Wrap(ValidateHTMLText(p.HTMLNotes), "Invalid project html_notes")
+}
+
+// Access levels for project members
+const (
+	AccessLevelAdmin     = "admin"
+	AccessLevelEditor    = "editor"
+	AccessLevelCommenter = "commenter"
+	AccessLevelViewer    = "viewer"
+)
+
+// PermalinkURL returns a link to this project in the Asana web app. The
+// permalink_url field is used if it was requested when loading the project,
+// otherwise a link is constructed from the project ID.
+func (p *Project) PermalinkURL() string {
+	if p.Permalink != "" {
+		return p.Permalink
+	}
+	return fmt.Sprintf("%s/0/%s/list", AppURL, p.ID)
 }
 
 func (p *Project) GetID() string {
@@ -173,6 +244,24 @@ func (p *Project) Fetch(client *Client, opts ...*Options) error {
 	return err
 }
 
+// MyAccessLevel returns the access level of the authorized user on this
+// project, which can be used to check whether a write will be permitted
+// before attempting it.
+func (p *Project) MyAccessLevel(client *Client) (string, error) {
+	client.trace("Loading access level for project %q", p.Name)
+
+	result := &Project{}
+	_, err := client.get(fmt.Sprintf("/projects/%s", p.ID), nil, result, &Options{
+		Fields: []string{"current_user_access_level"},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	p.CurrentUserAccessLevel = result.CurrentUserAccessLevel
+	return p.CurrentUserAccessLevel, nil
+}
+
 // Update
 //
 // When using this method, it is best to specify only those fields you wish to change,
@@ -186,6 +275,82 @@ func (p *Project) Update(client *Client, request *UpdateProjectRequest, opts ...
 	return err
 }
 
+// Archive archives this project. Archived projects are hidden from the UI
+// by default but keep their tasks, and can be restored with Unarchive. The
+// project is updated with the response from the API.
+func (p *Project) Archive(client *Client) error {
+	return p.Update(client, &UpdateProjectRequest{ProjectBase: ProjectBase{Archived: Bool(true)}})
+}
+
+// Unarchive restores an archived project. The project is updated with the
+// response from the API.
+func (p *Project) Unarchive(client *Client) error {
+	return p.Update(client, &UpdateProjectRequest{ProjectBase: ProjectBase{Archived: Bool(false)}})
+}
+
+// SetNotes replaces the notes of this project with plain text
+func (p *Project) SetNotes(client *Client, notes string) error {
+	return p.Update(client, &UpdateProjectRequest{ProjectBase: ProjectBase{Notes: notes}})
+}
+
+// SetHTMLNotes replaces the notes of this project with rich text, which must
+// be valid Asana rich text wrapped in a <body> element. The html_notes field
+// is requested in the response, so the project's HTMLNotes is updated along
+// with its Notes.
+func (p *Project) SetHTMLNotes(client *Client, htmlNotes string) error {
+	if htmlNotes == "" {
+		return errors.New("Use SetNotes to clear the notes of a project")
+	}
+	return p.Update(client, &UpdateProjectRequest{ProjectBase: ProjectBase{HTMLNotes: htmlNotes}}, &Options{
+		Fields: []string{"name", "notes", "html_notes"},
+	})
+}
+
+// AddMembers adds users to the members of this project. Members may be given
+// as user IDs, email addresses or "me".
+//
+// Long lists are sent in several requests, and the errors of any which fail
+// are returned as a MultiError.
+func (p *Project) AddMembers(client *Client, members []string) error {
+	client.trace("Adding members to project %q", p.Name)
+
+	return client.postChunked(fmt.Sprintf("/projects/%s/addMembers", p.ID), "members", members, maxIDsPerRequest, p)
+}
+
+// RemoveMembers removes users from the members of this project
+func (p *Project) RemoveMembers(client *Client, members []string) error {
+	client.trace("Removing members from project %q", p.Name)
+
+	return client.postChunked(fmt.Sprintf("/projects/%s/removeMembers", p.ID), "members", members, maxIDsPerRequest, p)
+}
+
+// SetOwner makes a user the owner of this project. A project has a single
+// owner, who must be one of its members: the members are loaded if they
+// were not fetched with the project, and an error is returned without
+// updating the project if the user is not among them.
+func (p *Project) SetOwner(client *Client, userGID string) error {
+	client.trace("Setting owner of project %q to %s", p.Name, userGID)
+
+	if p.Members == nil {
+		if err := p.Fetch(client, &Options{Fields: []string{"members"}}); err != nil {
+			return err
+		}
+	}
+
+	isMember := false
+	for _, member := range p.Members {
+		if member.ID == userGID {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return errors.Errorf("User %s is not a member of project %s", userGID, p.ID)
+	}
+
+	return p.Update(client, &UpdateProjectRequest{Owner: userGID})
+}
+
 // Projects returns a list of projects in this workspace
 func (w *Workspace) Projects(client *Client, options ...*Options) ([]*Project, *NextPage, error) {
 	client.trace("Listing projects in %q", w.Name)
@@ -223,52 +388,16 @@ func (w *Workspace) FavoriteProjects(client *Client, options ...*Options) ([]*Pr
 
 // AllProjects repeatedly pages through all available projects in a workspace
 func (w *Workspace) AllProjects(client *Client, options ...*Options) ([]*Project, error) {
-	var allProjects []*Project
-	nextPage := &NextPage{}
-
-	var projects []*Project
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		projects, nextPage, err = w.Projects(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
-
-		allProjects = append(allProjects, projects...)
-	}
-	return allProjects, nil
+	return listAll(client, 100, func(opts ...*Options) ([]*Project, *NextPage, error) {
+		return w.Projects(client, opts...)
+	}, options...)
 }
 
 // AllProjects repeatedly pages through all available projects in a workspace
 func (w *Workspace) AllFavoriteProjects(client *Client, options ...*Options) ([]*Project, error) {
-	var allProjects []*Project
-	nextPage := &NextPage{}
-
-	var projects []*Project
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		projects, nextPage, err = w.FavoriteProjects(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
-
-		allProjects = append(allProjects, projects...)
-	}
-	return allProjects, nil
+	return listAll(client, 100, func(opts ...*Options) ([]*Project, *NextPage, error) {
+		return w.FavoriteProjects(client, opts...)
+	}, options...)
 }
 
 // Projects returns a list of projects in this team
@@ -284,27 +413,9 @@ func (t *Team) Projects(client *Client, options ...*Options) ([]*Project, *NextP
 
 // AllProjects repeatedly pages through all available projects in a team
 func (t *Team) AllProjects(client *Client, options ...*Options) ([]*Project, error) {
-	var allProjects []*Project
-	nextPage := &NextPage{}
-
-	var projects []*Project
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
-
-		allOptions := append([]*Options{page}, options...)
-		projects, nextPage, err = t.Projects(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
-
-		allProjects = append(allProjects, projects...)
-	}
-	return allProjects, nil
+	return listAll(client, 100, func(opts ...*Options) ([]*Project, *NextPage, error) {
+		return t.Projects(client, opts...)
+	}, options...)
 }
 
 // CreateProject adds a new project to a workspace
@@ -326,3 +437,16 @@ func (t *Team) CreateProject(c *Client, project *CreateProjectRequest) (*Project
 	err := c.post(fmt.Sprintf("/teams/%s/projects", t.ID), project, result)
 	return result, err
 }
+
+// GID returns the globally unique ID of this Project
+func (p *Project) GID() string {
+	return p.ID
+}
+
+// Type returns the resource type of this Project
+func (p *Project) Type() string {
+	if p.ResourceType != "" {
+		return p.ResourceType
+	}
+	return "project"
+}
@@ -0,0 +1,136 @@
+package asana
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// QuickAdd is a task described by a single line of text, see ParseQuickAdd
+type QuickAdd struct {
+	// The name of the task, without the hints
+	Name string
+
+	// The project hint, without the leading #
+	Project string
+
+	// The assignee hint, without the leading @
+	Assignee string
+}
+
+// ParseQuickAdd parses a single line describing a task, such as
+// "Book flights #Travel @me". The line may end with a #project hint and an
+// @assignee hint, in either order.
+//
+// The parser is deliberately conservative: hints are only recognised at the
+// end of the line, so "Fix #42 in the parser" is named as written, and a
+// hint cannot contain spaces. A line with more than one hint of a kind, or
+// with only hints, is rejected rather than guessed at.
+func ParseQuickAdd(text string) (*QuickAdd, error) {
+	if strings.ContainsAny(text, "\r\n") {
+		return nil, errors.New("Quick add text must be a single line")
+	}
+
+	words := strings.Fields(text)
+	result := &QuickAdd{}
+
+	for len(words) > 0 {
+		word := words[len(words)-1]
+		if len(word) < 2 {
+			break
+		}
+
+		var hint *string
+		switch word[0] {
+		case '#':
+			hint = &result.Project
+		case '@':
+			hint = &result.Assignee
+		}
+		if hint == nil {
+			break
+		}
+		if *hint != "" {
+			return nil, errors.Errorf("Quick add text has more than one %c hint", word[0])
+		}
+
+		*hint = word[1:]
+		words = words[:len(words)-1]
+	}
+
+	result.Name = strings.Join(words, " ")
+	if result.Name == "" {
+		return nil, errors.New("Quick add text must include a task name")
+	}
+	return result, nil
+}
+
+// QuickAddTask creates a task in this workspace from a single line of text,
+// see ParseQuickAdd. The assignee and project are used unless the text has
+// hints for them.
+//
+// An assignee hint is passed to the API as given, so it may be a user ID,
+// an email address or "me". A project hint is either a project ID or the
+// name of a project in this workspace, compared without regard to case, see
+// ResolveProject.
+func (w *Workspace) QuickAddTask(client *Client, text string, assignee, project string) (*Task, error) {
+	parsed, err := ParseQuickAdd(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Assignee != "" {
+		assignee = parsed.Assignee
+	}
+	if parsed.Project != "" {
+		project, err = w.quickAddProject(client, parsed.Project)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request := &CreateTaskRequest{
+		TaskBase:  TaskBase{Name: parsed.Name},
+		Workspace: w.ID,
+		Assignee:  assignee,
+	}
+	if project != "" {
+		request.Projects = []string{project}
+	}
+	return client.CreateTask(request)
+}
+
+// quickAddProject returns the ID of the project named by a hint. A hint of
+// digits is tried as a project ID first, and otherwise as a name, so that a
+// project named e.g. "2024" can still be given by name.
+func (w *Workspace) quickAddProject(client *Client, hint string) (string, error) {
+	if isGID(hint) {
+		project := &Project{ID: hint}
+		err := project.Fetch(client, &Options{Fields: []string{"name"}})
+		if err == nil {
+			return project.ID, nil
+		}
+		if !IsNotFoundError(err) {
+			return "", err
+		}
+	}
+
+	project, err := w.ResolveProject(client, hint)
+	if err != nil {
+		return "", err
+	}
+	return project.ID, nil
+}
+
+// isGID returns true if s has the form of a globally unique ID
+func isGID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package asana
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected QuickAdd
+	}{
+		{"Book flights", QuickAdd{Name: "Book flights"}},
+		{"Book flights #Travel", QuickAdd{Name: "Book flights", Project: "Travel"}},
+		{"Book flights @me", QuickAdd{Name: "Book flights", Assignee: "me"}},
+		{"Book flights #Travel @me", QuickAdd{Name: "Book flights", Project: "Travel", Assignee: "me"}},
+		{"Book flights @jo@example.com #123", QuickAdd{Name: "Book flights", Project: "123", Assignee: "jo@example.com"}},
+		{"  Book   flights  ", QuickAdd{Name: "Book flights"}},
+		{"Fix #42 in the parser", QuickAdd{Name: "Fix #42 in the parser"}},
+		{"Email jo@example.com", QuickAdd{Name: "Email jo@example.com"}},
+		{"Price in # and @", QuickAdd{Name: "Price in # and @"}},
+	}
+	for _, test := range tests {
+		actual, err := ParseQuickAdd(test.text)
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %s", test.text, err)
+			continue
+		}
+		if *actual != test.expected {
+			t.Errorf("Expected %+v for %q, but saw %+v", test.expected, test.text, *actual)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"#Travel @me",
+		"Book flights #Travel #Holidays",
+		"Book flights @me @you",
+		"Book flights\nand hotels",
+	}
+	for _, text := range invalid {
+		if _, err := ParseQuickAdd(text); err == nil {
+			t.Errorf("Expected an error for %q", text)
+		}
+	}
+}
+
+func TestWorkspace_QuickAddTask_NumericProjectName(t *testing.T) {
+	var created map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/2024":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": [{"message": "project: Unknown object: 2024"}]}`))
+		case "/workspaces/1/typeahead":
+			w.Write([]byte(`{"data": [{"gid": "99", "name": "2024"}]}`))
+		case "/tasks":
+			body := struct {
+				Data map[string]interface{} `json:"data"`
+			}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			created = body.Data
+			w.Write([]byte(`{"data": {"gid": "5"}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	})
+
+	workspace := &Workspace{ID: "1"}
+	if _, err := workspace.QuickAddTask(client, "Plan the year #2024", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if projects, ok := created["projects"].([]interface{}); !ok || len(projects) != 1 || projects[0] != "99" {
+		t.Errorf("Expected the task in the project named 2024, but saw %v", created["projects"])
+	}
+}
@@ -0,0 +1,129 @@
+package asana
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRequestCosts weights endpoints which Asana limits more strictly
+// than the standard rate limit of 1500 requests per minute. The search API
+// is limited to 60 requests per minute, so a search costs 25 requests, and
+// each action of a batch request counts against the limit, so a batch may
+// cost up to 10.
+var defaultRequestCosts = map[string]int{
+	"/workspaces/*/tasks/search": 25,
+	"/batch":                     10,
+}
+
+// DefaultRequestCosts returns a copy of the request costs used when
+// Client.RequestCosts is not set, which can be modified and assigned to
+// RequestCosts to change the cost of some endpoints
+func DefaultRequestCosts() map[string]int {
+	costs := make(map[string]int, len(defaultRequestCosts))
+	for pattern, cost := range defaultRequestCosts {
+		costs[pattern] = cost
+	}
+	return costs
+}
+
+// rateLimiter is a token bucket holding the client's remaining request
+// budget, see Client.RateLimit
+type rateLimiter struct {
+	lock    sync.Mutex
+	tokens  float64
+	updated time.Time
+}
+
+// waitForBudget blocks until the client's rate limit budget allows the
+// request, or the request's context is done. Requests are free if no rate
+// limit is set.
+func (c *Client) waitForBudget(request *http.Request) error {
+	if c.RateLimit <= 0 {
+		return nil
+	}
+
+	limit := float64(c.RateLimit)
+	cost := float64(c.requestCost(request.URL.Path))
+	if cost > limit {
+		cost = limit
+	}
+
+	for {
+		wait := c.takeBudget(limit, cost)
+		if wait <= 0 {
+			return nil
+		}
+
+		c.trace("Rate limit budget exhausted, waiting %s", wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return request.Context().Err()
+		}
+	}
+}
+
+// takeBudget refills the bucket for the elapsed time and takes cost from it.
+// If the budget is insufficient, nothing is taken and the time until it will
+// be sufficient is returned.
+func (c *Client) takeBudget(limit, cost float64) time.Duration {
+	c.limiter.lock.Lock()
+	defer c.limiter.lock.Unlock()
+
+	now := time.Now()
+	if c.limiter.updated.IsZero() {
+		c.limiter.tokens = limit
+	} else {
+		c.limiter.tokens += now.Sub(c.limiter.updated).Minutes() * limit
+		if c.limiter.tokens > limit {
+			c.limiter.tokens = limit
+		}
+	}
+	c.limiter.updated = now
+
+	if c.limiter.tokens >= cost {
+		c.limiter.tokens -= cost
+		return 0
+	}
+	return time.Duration((cost - c.limiter.tokens) / limit * float64(time.Minute))
+}
+
+// requestCost returns the cost of a request to the given URL path, from
+// RequestCosts or the default costs. Requests to other endpoints cost 1.
+func (c *Client) requestCost(path string) int {
+	costs := c.RequestCosts
+	if costs == nil {
+		costs = defaultRequestCosts
+	}
+
+	if c.BaseURL != nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(c.BaseURL.Path, "/"))
+	}
+	for pattern, cost := range costs {
+		if matchPathPattern(pattern, path) {
+			return cost
+		}
+	}
+	return 1
+}
+
+// matchPathPattern matches a path against a pattern in which * stands for
+// any single segment, such as an object ID
+func matchPathPattern(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if segment != "*" && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
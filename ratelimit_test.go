@@ -0,0 +1,85 @@
+package asana
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRequestCost(t *testing.T) {
+	base, _ := url.Parse("https://app.asana.com/api/1.0")
+	client := &Client{BaseURL: base}
+
+	cases := map[string]int{
+		"/api/1.0/workspaces/123/tasks/search": 25,
+		"/api/1.0/batch":                       10,
+		"/api/1.0/tasks/456":                   1,
+		"/api/1.0/workspaces/123/tasks":        1,
+	}
+	for path, expected := range cases {
+		if cost := client.requestCost(path); cost != expected {
+			t.Errorf("Expected %s to cost %d, but saw %d", path, expected, cost)
+		}
+	}
+
+	client.RequestCosts = map[string]int{"/tasks/*": 3}
+	if cost := client.requestCost("/api/1.0/tasks/456"); cost != 3 {
+		t.Errorf("Expected the configured cost 3, but saw %d", cost)
+	}
+	if cost := client.requestCost("/api/1.0/workspaces/123/tasks/search"); cost != 1 {
+		t.Errorf("Expected configured costs to replace the defaults, but saw %d", cost)
+	}
+}
+
+func TestWaitForBudget(t *testing.T) {
+	base, _ := url.Parse("https://app.asana.com/api/1.0")
+	client := &Client{BaseURL: base, RateLimit: 30}
+
+	search, _ := http.NewRequest(http.MethodGet, "https://app.asana.com/api/1.0/workspaces/1/tasks/search", nil)
+	if err := client.waitForBudget(search); err != nil {
+		t.Fatal(err)
+	}
+
+	// 5 requests remain, not enough for another search
+	if wait := client.takeBudget(30, 25); wait <= 0 {
+		t.Errorf("Expected a search to wait for the budget")
+	}
+	for i := 0; i < 5; i++ {
+		if wait := client.takeBudget(30, 1); wait > 0 {
+			t.Fatalf("Expected request %d to be within the budget, but had to wait %s", i, wait)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	get, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://app.asana.com/api/1.0/tasks/1", nil)
+	if err := client.waitForBudget(get); err != context.DeadlineExceeded {
+		t.Errorf("Expected the wait to end with the context, but saw %v", err)
+	}
+}
+
+func TestDefaultRequestCosts_ReturnsCopy(t *testing.T) {
+	costs := DefaultRequestCosts()
+	costs["/batch"] = 1
+
+	client := &Client{}
+	if cost := client.requestCost("/batch"); cost != 10 {
+		t.Errorf("Expected modifying the copy not to change the defaults, but saw %d", cost)
+	}
+}
+
+func TestSend_OpenCircuitKeepsBudget(t *testing.T) {
+	base, _ := url.Parse("https://app.asana.com/api/1.0")
+	client := &Client{BaseURL: base, RateLimit: 30, CircuitThreshold: 1, CircuitCooldown: time.Minute}
+	client.recordResult(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+
+	search, _ := http.NewRequest(http.MethodGet, "https://app.asana.com/api/1.0/workspaces/1/tasks/search", nil)
+	if _, err := client.send(search); !IsCircuitOpen(err) {
+		t.Fatalf("Expected the breaker to reject the request, but saw %v", err)
+	}
+	if wait := client.takeBudget(30, 30); wait > 0 {
+		t.Errorf("Expected a rejected request not to spend the budget, but had to wait %s", wait)
+	}
+}
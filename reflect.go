@@ -3,6 +3,8 @@ package asana
 import (
 	"reflect"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // Fields gets all valid JSON fields for a type
@@ -36,3 +38,138 @@ func gatherFields(t reflect.Type, result *Options) {
 		}
 	}
 }
+
+// FieldsOf returns the opt_fields paths for the JSON fields of a struct, or
+// a pointer to a struct.
+//
+// Fields tagged with `asana:"expand"` are expanded into the nested fields of
+// their struct type (e.g. "assignee.name", "assignee.email"), which the API
+// would otherwise return in compact form. Pointer and slice fields are
+// expanded through to their element type. Self-referencing types are only
+// expanded once along any path.
+func FieldsOf(v interface{}) []string {
+	t := indirectType(reflect.TypeOf(v))
+	if t == nil || t.Kind() != reflect.Struct {
+		panic("Invalid type requested")
+	}
+
+	var result []string
+	gatherFieldPaths(t, "", map[reflect.Type]bool{t: true}, &result)
+	return result
+}
+
+func gatherFieldPaths(t reflect.Type, prefix string, visiting map[reflect.Type]bool, result *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.Anonymous {
+			if et := indirectType(f.Type); et.Kind() == reflect.Struct {
+				gatherFieldPaths(et, prefix, visiting, result)
+			}
+			continue
+		}
+
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+
+		path := prefix + name
+		*result = append(*result, path)
+
+		if f.Tag.Get("asana") != "expand" {
+			continue
+		}
+
+		et := indirectType(f.Type)
+		if et.Kind() != reflect.Struct || visiting[et] {
+			continue
+		}
+
+		visiting[et] = true
+		gatherFieldPaths(et, path+".", visiting, result)
+		delete(visiting, et)
+	}
+}
+
+// jsonName returns the JSON name of a struct field, or an empty string if
+// the field is not serialized
+func jsonName(f reflect.StructField) string {
+	jsonTag := f.Tag.Get("json")
+	if jsonTag == "" {
+		return ""
+	}
+
+	name := strings.Split(jsonTag, ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// indirectType strips pointer and slice types down to their element type
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	return t
+}
+
+// ValidateFields checks that each opt_fields path names a JSON field of v,
+// which should be a struct or a pointer or slice of structs. Each segment of
+// a dotted path must name a field of the struct reached by the segments
+// before it. Paths which pass through a field without a struct type, such as
+// a map or interface, are accepted from that point on.
+func ValidateFields(v interface{}, fields []string) error {
+	t := indirectType(reflect.TypeOf(v))
+	if t == nil || t.Kind() != reflect.Struct {
+		return errors.Errorf("Unable to validate fields for type %T", v)
+	}
+
+	for _, path := range fields {
+		current := t
+		for _, name := range strings.Split(path, ".") {
+			if current.Kind() != reflect.Struct {
+				break
+			}
+
+			f, ok := fieldByJSONName(current, name)
+			if !ok {
+				return errors.Errorf("Unknown field %q in %q for type %s", name, path, t.Name())
+			}
+			current = indirectType(f.Type)
+		}
+	}
+	return nil
+}
+
+// fieldByJSONName finds the struct field serialized with the given JSON
+// name, including fields of embedded structs
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.Anonymous {
+			if et := indirectType(f.Type); et.Kind() == reflect.Struct {
+				if found, ok := fieldByJSONName(et, name); ok {
+					return found, true
+				}
+			}
+			continue
+		}
+
+		if jsonName(f) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// checkFields validates the requested fields against the result type when
+// the StrictFields option is set
+func checkFields(result interface{}, options *Options) error {
+	if !IsTrue(options.StrictFields) || result == nil || len(options.Fields) == 0 {
+		return nil
+	}
+	return ValidateFields(result, options.Fields)
+}
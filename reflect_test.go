@@ -0,0 +1,57 @@
+package asana
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fieldsOfOwner struct {
+	ID    string `json:"gid,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"-"`
+}
+
+type fieldsOfNode struct {
+	ID string `json:"gid,omitempty"`
+
+	fieldsOfBase
+
+	Owner     *fieldsOfOwner   `json:"owner,omitempty" asana:"expand"`
+	Members   []*fieldsOfOwner `json:"members,omitempty" asana:"expand"`
+	Parent    *fieldsOfNode    `json:"parent,omitempty" asana:"expand"`
+	Followers []*fieldsOfOwner `json:"followers,omitempty"`
+}
+
+type fieldsOfBase struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+func TestFieldsOf(t *testing.T) {
+	expected := []string{
+		"gid",
+		"notes",
+		"owner", "owner.gid", "owner.name",
+		"members", "members.gid", "members.name",
+		"parent",
+		"followers",
+	}
+
+	if fields := FieldsOf(&fieldsOfNode{}); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected fields %v, but saw %v", expected, fields)
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	var result []*fieldsOfNode
+
+	valid := []string{"gid", "notes", "owner.name", "members.gid", "parent.owner.name"}
+	if err := ValidateFields(&result, valid); err != nil {
+		t.Errorf("Expected fields to be valid, but saw %v", err)
+	}
+
+	for _, path := range []string{"onwer.name", "owner.email", "parent.notse"} {
+		if err := ValidateFields(&result, []string{path}); err == nil {
+			t.Errorf("Expected an error for field %q", path)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package asana
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type typeaheadRequestParams struct {
+	ResourceType string `url:"resource_type"`
+	Query        string `url:"query"`
+	Count        int    `url:"count,omitempty"`
+}
+
+// typeaheadCount is the number of typeahead results searched for an exact
+// match, the maximum the API returns
+const typeaheadCount = 100
+
+// resolveCacheDuration is how long a name resolved by ResolveProject,
+// ResolveUser or ResolveTag is cached
+const resolveCacheDuration = 5 * time.Minute
+
+// resolvedName is a cached result of resolving a name
+type resolvedName struct {
+	id       string
+	name     string
+	resolved time.Time
+}
+
+// ResolveProject finds the project in this workspace with the given name,
+// compared without regard to case. This is meant for scripts where people
+// supply names rather than IDs. The project is returned in compact form,
+// with its ID and name.
+//
+// Projects are looked up with the typeahead API, and the ID found is cached
+// on the client for five minutes, so repeated lookups of a name do not make
+// further requests; see ClearResolveCache. An error listing the candidates
+// is returned if several projects have the name.
+func (w *Workspace) ResolveProject(client *Client, name string) (*Project, error) {
+	return resolveByName(client, w, "project", name,
+		func(p *Project) string { return p.Name },
+		func(id, name string) *Project { return &Project{ID: id, ProjectBase: ProjectBase{Name: name}} })
+}
+
+// ResolveUser finds the user in this workspace with the given name, like
+// ResolveProject. To find a user by email address, use UserIDByEmail.
+func (w *Workspace) ResolveUser(client *Client, name string) (*User, error) {
+	return resolveByName(client, w, "user", name,
+		func(u *User) string { return u.Name },
+		func(id, name string) *User { return &User{ID: id, Name: name} })
+}
+
+// ResolveTag finds the tag in this workspace with the given name, like
+// ResolveProject
+func (w *Workspace) ResolveTag(client *Client, name string) (*Tag, error) {
+	return resolveByName(client, w, "tag", name,
+		func(t *Tag) string { return t.Name },
+		func(id, name string) *Tag { return &Tag{ID: id, TagBase: TagBase{Name: name}} })
+}
+
+// ClearResolveCache forgets the names resolved by ResolveProject,
+// ResolveUser and ResolveTag, e.g. after renaming or deleting objects, so
+// that they are looked up again
+func (c *Client) ClearResolveCache() {
+	c.resolveLock.Lock()
+	defer c.resolveLock.Unlock()
+
+	c.resolved = nil
+}
+
+// resolveByName returns the only typeahead result in a workspace whose name
+// equals the given name without regard to case. Each call returns a new
+// compact object, so callers can modify it without affecting each other.
+func resolveByName[T Resource](client *Client, w *Workspace, resourceType, name string, nameOf func(T) string, compact func(id, name string) T) (T, error) {
+	var zero T
+
+	key := w.ID + "/" + resourceType + "/" + strings.ToLower(name)
+	client.resolveLock.Lock()
+	cached, ok := client.resolved[key]
+	client.resolveLock.Unlock()
+	if ok && time.Since(cached.resolved) < resolveCacheDuration {
+		return compact(cached.id, cached.name), nil
+	}
+
+	client.trace("Resolving %s %q in workspace %s", resourceType, name, w.ID)
+
+	var candidates []T
+	query := typeaheadRequestParams{
+		ResourceType: resourceType,
+		Query:        name,
+		Count:        typeaheadCount,
+	}
+	_, err := client.get(fmt.Sprintf("/workspaces/%s/typeahead", w.ID), query, &candidates, &Options{Fields: []string{"name"}})
+	if err != nil {
+		return zero, errors.Wrapf(err, "Resolve %s %q", resourceType, name)
+	}
+
+	var matches []T
+	for _, candidate := range candidates {
+		if strings.EqualFold(nameOf(candidate), name) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return zero, errors.Errorf("No %s named %q in workspace %q", resourceType, name, w.Name)
+	case 1:
+	default:
+		var listed []string
+		for _, match := range matches {
+			listed = append(listed, fmt.Sprintf("%q (%s)", nameOf(match), match.GID()))
+		}
+		return zero, errors.Errorf("%d %ss named %q in workspace %q: %s", len(matches), resourceType, name, w.Name, strings.Join(listed, ", "))
+	}
+
+	client.resolveLock.Lock()
+	if client.resolved == nil {
+		client.resolved = make(map[string]resolvedName)
+	}
+	client.resolved[key] = resolvedName{id: matches[0].GID(), name: nameOf(matches[0]), resolved: time.Now()}
+	client.resolveLock.Unlock()
+
+	return matches[0], nil
+}
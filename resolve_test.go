@@ -0,0 +1,61 @@
+package asana
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWorkspace_ResolveProject(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/workspaces/1/typeahead" || r.URL.Query().Get("resource_type") != "project" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"data": [
+			{"gid": "10", "name": "Roadmap 2026"},
+			{"gid": "11", "name": "Roadmap"},
+			{"gid": "12", "name": "Launch"},
+			{"gid": "13", "name": "launch"}
+		]}`))
+	})
+	workspace := &Workspace{ID: "1", Name: "Acme"}
+
+	project, err := workspace.ResolveProject(client, "roadmap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.ID != "11" {
+		t.Errorf("Expected the exact match 11, but saw %s", project.ID)
+	}
+
+	project.Name = "Changed by the caller"
+	cached, err := workspace.ResolveProject(client, "Roadmap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the second lookup to be cached, but saw %d requests", requests)
+	}
+	if cached == project || cached.ID != "11" || cached.Name != "Roadmap" {
+		t.Errorf("Expected a new compact project from the cache, but saw %+v", cached)
+	}
+
+	client.ClearResolveCache()
+	if _, err := workspace.ResolveProject(client, "Roadmap"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected the lookup to be repeated after clearing the cache, but saw %d requests", requests)
+	}
+
+	_, err = workspace.ResolveProject(client, "Launch")
+	if err == nil || !strings.Contains(err.Error(), "(12)") || !strings.Contains(err.Error(), "(13)") {
+		t.Errorf("Expected an ambiguity error listing both candidates, but saw %v", err)
+	}
+
+	if _, err := workspace.ResolveProject(client, "Roadmap 2027"); err == nil {
+		t.Error("Expected an error when no project has the name")
+	}
+}
@@ -0,0 +1,96 @@
+package asana
+
+// The following constructors return unexpanded resources holding only a
+// GID. Call Fetch on the result to load the remaining fields, or pass it
+// straight to methods which only need the ID. They make no requests and do
+// not use the client they are called on: the resource is not bound to it,
+// and the client must still be passed to each method called on the result.
+
+// Workspace returns an unexpanded workspace with the given ID
+func (c *Client) Workspace(id string) *Workspace {
+	return &Workspace{ID: id}
+}
+
+// Task returns an unexpanded task with the given ID
+func (c *Client) Task(id string) *Task {
+	return &Task{ID: id}
+}
+
+// Project returns an unexpanded project with the given ID
+func (c *Client) Project(id string) *Project {
+	return &Project{ID: id}
+}
+
+// User returns an unexpanded user with the given ID
+func (c *Client) User(id string) *User {
+	return &User{ID: id}
+}
+
+// Section returns an unexpanded section with the given ID
+func (c *Client) Section(id string) *Section {
+	return &Section{ID: id}
+}
+
+// Tag returns an unexpanded tag with the given ID
+func (c *Client) Tag(id string) *Tag {
+	return &Tag{ID: id}
+}
+
+// Story returns an unexpanded story with the given ID
+func (c *Client) Story(id string) *Story {
+	return &Story{ID: id}
+}
+
+// Attachment returns an unexpanded attachment with the given ID
+func (c *Client) Attachment(id string) *Attachment {
+	return &Attachment{ID: id}
+}
+
+// Refresh reloads this task, discarding any fields loaded before. Fetch
+// decodes the response into the existing task, so fields which are not
+// included in the response keep their previous values; Refresh instead
+// replaces the task with a freshly loaded copy, which is what is wanted
+// after being told of an external change, e.g. by a webhook. The task is
+// left unchanged if the request fails.
+func (t *Task) Refresh(client *Client, opts ...*Options) error {
+	fresh := &Task{ID: t.ID}
+	if err := fresh.Fetch(client, opts...); err != nil {
+		return err
+	}
+	*t = *fresh
+	return nil
+}
+
+// Refresh reloads this project, discarding any fields loaded before,
+// rather than merging the response into it as Fetch does. The project is
+// left unchanged if the request fails.
+func (p *Project) Refresh(client *Client, opts ...*Options) error {
+	fresh := &Project{ID: p.ID}
+	if err := fresh.Fetch(client, opts...); err != nil {
+		return err
+	}
+	*p = *fresh
+	return nil
+}
+
+// Refresh reloads this workspace like Task.Refresh, discarding any fields
+// loaded before. The workspace is left unchanged if the request fails.
+func (w *Workspace) Refresh(client *Client, opts ...*Options) error {
+	fresh := &Workspace{ID: w.ID}
+	if err := fresh.Fetch(client, opts...); err != nil {
+		return err
+	}
+	*w = *fresh
+	return nil
+}
+
+// Refresh reloads this user like Task.Refresh, replacing it with a freshly
+// loaded copy. The user is left unchanged if the request fails.
+func (u *User) Refresh(client *Client, opts ...*Options) error {
+	fresh := &User{ID: u.ID}
+	if err := fresh.Fetch(client, opts...); err != nil {
+		return err
+	}
+	*u = *fresh
+	return nil
+}
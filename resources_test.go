@@ -0,0 +1,20 @@
+package asana
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestTask_Refresh_DiscardsStaleFields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"gid": "1", "name": "Renamed"}}`))
+	})
+
+	task := &Task{ID: "1", TaskBase: TaskBase{Name: "Old", Notes: "Since removed"}}
+	if err := task.Refresh(client); err != nil {
+		t.Fatal(err)
+	}
+	if task.Name != "Renamed" || task.Notes != "" {
+		t.Errorf("Expected only the fresh fields to remain, but saw %q and %q", task.Name, task.Notes)
+	}
+}
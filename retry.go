@@ -0,0 +1,94 @@
+package asana
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	// maxRetries is the number of times a request is retried after a
+	// rate limit or server error before giving up
+	maxRetries = 3
+
+	// retryBaseDelay is the delay before the first retry of a server error,
+	// doubled on each subsequent attempt
+	retryBaseDelay = time.Second
+)
+
+// Jitter selects how retry delays are randomized, so that many clients
+// retrying at the same time do not keep retrying in lockstep
+type Jitter string
+
+const (
+	// JitterNone uses the exponential backoff delay unchanged. This is the
+	// default.
+	JitterNone Jitter = "none"
+
+	// JitterFull picks a random delay between zero and the backoff delay
+	JitterFull Jitter = "full"
+
+	// JitterEqual keeps half of the backoff delay and randomizes the other
+	// half
+	JitterEqual Jitter = "equal"
+)
+
+// retry calls fn until it succeeds, returns an error which is not a rate
+// limit or server error, or maxRetries is exhausted. Rate limited requests
+// wait for the duration given in the Retry-After header.
+func (c *Client) retry(fn func() error) error {
+	return c.retryWhen(func(err error) bool {
+		return IsRateLimited(err) || IsRecoverableError(err)
+	}, fn)
+}
+
+// retryRateLimited is like retry, but only retries rate limited requests.
+// These were rejected without being applied, so it is safe for requests
+// which must not be repeated, unlike a server error which may arrive after
+// the request was carried out.
+func (c *Client) retryRateLimited(fn func() error) error {
+	return c.retryWhen(IsRateLimited, fn)
+}
+
+// retryWhen calls fn until it succeeds, returns an error for which retryable
+// is false, or maxRetries is exhausted
+func (c *Client) retryWhen(retryable func(error) bool, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= maxRetries || !retryable(err) {
+			return err
+		}
+
+		delay := c.backoff(attempt, err)
+		c.info("Retrying in %v after error: %v", delay, err)
+		time.Sleep(delay)
+	}
+}
+
+// backoff returns the delay before the given retry attempt. The Retry-After
+// duration of a rate limit error is a minimum, so RetryJitter is only applied
+// to the exponential backoff of other errors.
+func (c *Client) backoff(attempt int, err error) time.Duration {
+	if IsRateLimited(err) {
+		if e, ok := IsAsanaError(err); ok && e.RetryAfter > 0 {
+			return e.RetryAfter
+		}
+	}
+	return c.RetryJitter.apply(retryBaseDelay << uint(attempt))
+}
+
+// apply randomizes a backoff delay according to the jitter algorithm
+func (j Jitter) apply(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+
+	switch j {
+	case JitterFull:
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	case JitterEqual:
+		half := delay / 2
+		return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+	default:
+		return delay
+	}
+}
@@ -0,0 +1,49 @@
+package asana
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter_Bounds(t *testing.T) {
+	delay := 4 * time.Second
+
+	tests := []struct {
+		jitter   Jitter
+		min, max time.Duration
+	}{
+		{"", delay, delay},
+		{JitterNone, delay, delay},
+		{JitterFull, 0, delay},
+		{JitterEqual, delay / 2, delay},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			if d := test.jitter.apply(delay); d < test.min || d > test.max {
+				t.Fatalf("Expected %q jitter of %v to be between %v and %v, but saw %v", test.jitter, delay, test.min, test.max, d)
+			}
+		}
+	}
+}
+
+func TestBackoff_Exponential(t *testing.T) {
+	c := &Client{RetryJitter: JitterEqual}
+	err := &Error{StatusCode: 503}
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		max := retryBaseDelay << uint(attempt)
+		if d := c.backoff(attempt, err); d < max/2 || d > max {
+			t.Errorf("Expected attempt %d backoff between %v and %v, but saw %v", attempt, max/2, max, d)
+		}
+	}
+}
+
+func TestBackoff_RetryAfter(t *testing.T) {
+	c := &Client{RetryJitter: JitterFull}
+	err := &Error{StatusCode: 429, RetryAfter: 30 * time.Second}
+
+	if d := c.backoff(0, err); d != 30*time.Second {
+		t.Errorf("Expected rate limited backoff of 30s, but saw %v", d)
+	}
+}
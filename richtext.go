@@ -0,0 +1,262 @@
+package asana
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Rich text fields such as StoryBase.HTMLText and TaskBase.HTMLNotes accept
+// a restricted, XML-valid subset of HTML wrapped in a <body> element.
+
+// richTextTags lists the elements accepted in rich text, and the attributes
+// accepted on each
+var richTextTags = map[string][]string{
+	"body":       nil,
+	"h1":         nil,
+	"h2":         nil,
+	"strong":     nil,
+	"em":         nil,
+	"u":          nil,
+	"s":          nil,
+	"code":       nil,
+	"pre":        nil,
+	"blockquote": nil,
+	"ol":         nil,
+	"ul":         nil,
+	"li":         nil,
+	"hr":         nil,
+	"a":          {"href", "data-asana-gid", "data-asana-accessible", "data-asana-dynamic", "data-asana-type"},
+}
+
+// MentionUser returns rich text markup which @-mentions a user. The user is
+// notified when the markup is posted in a comment or task description.
+//
+// Asana renders the link with the current name of the user, the name given
+// here is the text content of the link.
+func MentionUser(gid, name string) string {
+	return mention(gid, name)
+}
+
+// MentionTask returns rich text markup which links to a task. Asana renders
+// the link with the current name of the task, the name given here is the
+// text content of the link.
+func MentionTask(gid, name string) string {
+	return mention(gid, name)
+}
+
+func mention(gid, name string) string {
+	return fmt.Sprintf(`<a data-asana-gid="%s">%s</a>`, html.EscapeString(gid), html.EscapeString(name))
+}
+
+// ValidateHTMLText checks that rich text markup is accepted by the API: it
+// must be well-formed XML with a single <body> root element, and may only use
+// the supported subset of tags and attributes.
+func ValidateHTMLText(text string) error {
+	decoder := newRichTextDecoder(text)
+
+	depth := 0
+	roots := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "Invalid rich text")
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+				if t.Name.Local != "body" || roots > 1 {
+					return errors.New("Invalid rich text: must have a single <body> root element")
+				}
+			} else if t.Name.Local == "body" {
+				return errors.New("Invalid rich text: <body> may not be nested")
+			}
+			if err := validateRichTextElement(t); err != nil {
+				return err
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && strings.TrimSpace(string(t)) != "" {
+				return errors.New("Invalid rich text: text must be inside the <body> element")
+			}
+		}
+	}
+
+	if roots == 0 {
+		return errors.New("Invalid rich text: must have a single <body> root element")
+	}
+	return nil
+}
+
+func newRichTextDecoder(text string) *xml.Decoder {
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	decoder.Strict = true
+	decoder.Entity = xml.HTMLEntity
+	return decoder
+}
+
+func validateRichTextElement(e xml.StartElement) error {
+	attributes, ok := richTextTags[e.Name.Local]
+	if !ok || e.Name.Space != "" {
+		return errors.Errorf("Invalid rich text: unsupported tag <%s>", e.Name.Local)
+	}
+
+	for _, attr := range e.Attr {
+		if !containsString(attributes, attr.Name.Local) || attr.Name.Space != "" {
+			return errors.Errorf("Invalid rich text: unsupported attribute %q on <%s>", attr.Name.Local, e.Name.Local)
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// richTextAliases maps common HTML tags onto their supported rich text
+// equivalents
+var richTextAliases = map[string]string{
+	"b":      "strong",
+	"i":      "em",
+	"ins":    "u",
+	"strike": "s",
+	"del":    "s",
+	"h3":     "h2",
+	"h4":     "h2",
+	"h5":     "h2",
+	"h6":     "h2",
+	"tt":     "code",
+	"kbd":    "code",
+}
+
+// richTextDropped lists the elements which are removed along with their
+// content when sanitizing
+var richTextDropped = map[string]bool{
+	"script": true,
+	"style":  true,
+	"head":   true,
+	"title":  true,
+}
+
+var (
+	richTextEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	richTextAttrEscaper  = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	richTextLineBreakers = map[string]bool{"p": true, "div": true, "br": true, "tr": true}
+)
+
+// SanitizeHTMLText rewrites HTML into rich text markup accepted by the API.
+//
+// Common tags are mapped onto their supported equivalents (<b> becomes
+// <strong>, <h3> becomes <h2> and so on), other unsupported tags are removed
+// while keeping their text, and unsupported attributes are dropped.
+// Paragraphs and line breaks become newlines, and the result is wrapped in a
+// single <body> element. Markup which already passes ValidateHTMLText is
+// returned unchanged.
+//
+// The input may be HTML rather than strict XML: void elements such as <br>
+// need not be closed. An error is returned if the markup cannot be parsed.
+func SanitizeHTMLText(text string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var out strings.Builder
+	out.WriteString("<body>")
+
+	// The output tag for each open element, empty if it was removed
+	var open []string
+	skip := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "Invalid rich text")
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if skip > 0 || richTextDropped[name] {
+				skip++
+				continue
+			}
+			if alias, ok := richTextAliases[name]; ok {
+				name = alias
+			}
+
+			attributes, ok := richTextTags[name]
+			if !ok || name == "body" {
+				open = append(open, "")
+				continue
+			}
+
+			out.WriteString("<" + name)
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "" && containsString(attributes, attr.Name.Local) {
+					out.WriteString(" " + attr.Name.Local + `="` + richTextAttrEscaper.Replace(attr.Value) + `"`)
+				}
+			}
+			if name == "hr" {
+				out.WriteString("/>")
+				open = append(open, "")
+				continue
+			}
+			out.WriteString(">")
+			open = append(open, name)
+		case xml.EndElement:
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if len(open) == 0 {
+				continue
+			}
+			name := open[len(open)-1]
+			open = open[:len(open)-1]
+			if name != "" {
+				out.WriteString("</" + name + ">")
+			} else if richTextLineBreakers[strings.ToLower(t.Name.Local)] {
+				out.WriteString("\n")
+			}
+		case xml.CharData:
+			if skip == 0 {
+				out.WriteString(richTextEscaper.Replace(string(t)))
+			}
+		}
+	}
+
+	// Close any elements left open by the input
+	for i := len(open) - 1; i >= 0; i-- {
+		if open[i] != "" {
+			out.WriteString("</" + open[i] + ">")
+		}
+	}
+
+	out.WriteString("</body>")
+
+	result := out.String()
+	if err := ValidateHTMLText(result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
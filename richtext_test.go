@@ -0,0 +1,77 @@
+package asana
+
+import (
+	"testing"
+)
+
+func TestMentionUser(t *testing.T) {
+	text := "<body>Thanks " + MentionUser("123", "Jo & Co") + "!</body>"
+
+	if text != `<body>Thanks <a data-asana-gid="123">Jo &amp; Co</a>!</body>` {
+		t.Errorf("Unexpected mention markup %s", text)
+	}
+	if err := ValidateHTMLText(text); err != nil {
+		t.Errorf("Expected mention markup to be valid, but saw %v", err)
+	}
+}
+
+func TestValidateHTMLText(t *testing.T) {
+	valid := []string{
+		`<body></body>`,
+		`<body>Plain &nbsp;text</body>`,
+		`<body><strong>Bold</strong> <em>italic</em> <a href="https://example.com">link</a></body>`,
+		`<body><ul><li>One</li><li>Two</li></ul><hr/></body>`,
+	}
+	for _, text := range valid {
+		if err := ValidateHTMLText(text); err != nil {
+			t.Errorf("Expected %s to be valid, but saw %v", text, err)
+		}
+	}
+
+	invalid := []string{
+		``,
+		`Plain text`,
+		`<p>Paragraph</p>`,
+		`<body><p>Paragraph</p></body>`,
+		`<body><a onclick="x">link</a></body>`,
+		`<body><strong>Unclosed</body>`,
+		`<body></body><body></body>`,
+		`<body></body>trailing`,
+	}
+	for _, text := range invalid {
+		if err := ValidateHTMLText(text); err == nil {
+			t.Errorf("Expected %s to be invalid", text)
+		}
+	}
+}
+
+func TestSanitizeHTMLText(t *testing.T) {
+	brief := `<body><h1>Goals</h1>Ship the <strong>new</strong> onboarding.` +
+		`<ul><li>Owner: ` + MentionUser("123", "Jo") + `</li><li><a href="https://example.com/spec">Spec</a></li></ul>` +
+		`<hr/><h2>Risks</h2><ol><li><em>Timing</em> &amp; <code>scope</code></li></ol></body>`
+
+	text, err := SanitizeHTMLText(brief)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if text != brief {
+		t.Errorf("Expected valid brief to be unchanged, but saw %s", text)
+	}
+
+	tests := map[string]string{
+		`<p>One</p><p>Two<br>Three</p>`:                                   "<body>One\nTwo\nThree\n</body>",
+		`<h3>Plan</h3><b>bold</b> <i>italic</i>`:                          `<body><h2>Plan</h2><strong>bold</strong> <em>italic</em></body>`,
+		`<body><span class="x">kept</span><script>gone()</script></body>`: `<body>kept</body>`,
+		`<a href="https://example.com" target="_blank">link</a>`:          `<body><a href="https://example.com">link</a></body>`,
+	}
+	for input, expected := range tests {
+		text, err := SanitizeHTMLText(input)
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %v", input, err)
+			continue
+		}
+		if text != expected {
+			t.Errorf("Expected %s to sanitize to %q, but saw %q", input, expected, text)
+		}
+	}
+}
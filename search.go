@@ -0,0 +1,203 @@
+package asana
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SearchParams are the filters for an advanced search of the tasks in a
+// workspace. Unset fields are not filtered on.
+//
+// List filters combine the IDs they are given in one of three ways, chosen
+// by the suffix of the field name:
+//
+//   - Any matches tasks which have at least one of the values, e.g.
+//     ProjectsAny finds tasks in project A or project B
+//   - All matches tasks which have every one of the values, e.g. TagsAll
+//     finds tasks tagged with both tag A and tag B
+//   - Not excludes tasks which have any of the values
+//
+// Different filters are combined with AND, so ProjectsAny and TagsNot find
+// tasks in one of the projects which have none of the tags. Only some
+// properties support each combinator; there is no field for the others.
+//
+// Date filters come in two variants: the *On fields take a calendar date,
+// and the *At fields take a time. Use one variant or the other for each
+// property, not both.
+type SearchParams struct {
+	// Full text search on the task name and description
+	Text string `url:"text,omitempty"`
+
+	// Filters on the task subtype, e.g. TaskSubtypeMilestone
+	ResourceSubtype TaskSubtype `url:"resource_subtype,omitempty"`
+
+	// Filters on whether the tasks are completed
+	Completed *bool `url:"completed,omitempty"`
+
+	// Filters on whether the tasks are subtasks
+	IsSubtask *bool `url:"is_subtask,omitempty"`
+
+	// Filters on whether the tasks have attachments
+	HasAttachment *bool `url:"has_attachment,omitempty"`
+
+	// Filters on whether the tasks are waiting on other incomplete tasks
+	IsBlocked *bool `url:"is_blocked,omitempty"`
+
+	// Filters on whether other incomplete tasks are waiting on the tasks
+	IsBlocking *bool `url:"is_blocking,omitempty"`
+
+	// Matches tasks assigned to any of the given users. Users may be given
+	// as IDs or "me".
+	AssigneeAny []string `url:"assignee.any,omitempty,comma"`
+
+	// Matches tasks in any, or all, of the given projects
+	ProjectsAny []string `url:"projects.any,omitempty,comma"`
+	ProjectsAll []string `url:"projects.all,omitempty,comma"`
+
+	// Matches tasks in any, or all, of the given sections
+	SectionsAny []string `url:"sections.any,omitempty,comma"`
+	SectionsAll []string `url:"sections.all,omitempty,comma"`
+
+	// Matches tasks with any, or all, of the given tags
+	TagsAny []string `url:"tags.any,omitempty,comma"`
+	TagsAll []string `url:"tags.all,omitempty,comma"`
+
+	// Matches tasks in projects shared with any of the given teams
+	TeamsAny []string `url:"teams.any,omitempty,comma"`
+
+	// Matches tasks in projects in any of the given portfolios
+	PortfoliosAny []string `url:"portfolios.any,omitempty,comma"`
+
+	// Matches tasks created by any of the given users
+	CreatedByAny []string `url:"created_by.any,omitempty,comma"`
+
+	// Matches tasks assigned by any of the given users
+	AssignedByAny []string `url:"assigned_by.any,omitempty,comma"`
+
+	// Excludes tasks assigned to any of the given users. Users may be given
+	// as IDs or "me".
+	AssigneeNot []string `url:"assignee.not,omitempty,comma"`
+
+	// Excludes tasks in any of the given projects
+	ProjectsNot []string `url:"projects.not,omitempty,comma"`
+
+	// Excludes tasks in any of the given sections
+	SectionsNot []string `url:"sections.not,omitempty,comma"`
+
+	// Excludes tasks with any of the given tags
+	TagsNot []string `url:"tags.not,omitempty,comma"`
+
+	// Excludes tasks followed by any of the given users
+	FollowersNot []string `url:"followers.not,omitempty,comma"`
+
+	// Excludes tasks created by any of the given users
+	CreatedByNot []string `url:"created_by.not,omitempty,comma"`
+
+	// Excludes tasks assigned by any of the given users
+	AssignedByNot []string `url:"assigned_by.not,omitempty,comma"`
+
+	// Excludes tasks liked by any of the given users
+	LikedByNot []string `url:"liked_by.not,omitempty,comma"`
+
+	// Excludes tasks commented on by any of the given users
+	CommentedOnByNot []string `url:"commented_on_by.not,omitempty,comma"`
+
+	// Filters on the date on which the tasks were created
+	CreatedOn       *Date `url:"created_on,omitempty"`
+	CreatedOnBefore *Date `url:"created_on.before,omitempty"`
+	CreatedOnAfter  *Date `url:"created_on.after,omitempty"`
+
+	// Filters on the time at which the tasks were created
+	CreatedAtBefore *time.Time `url:"created_at.before,omitempty"`
+	CreatedAtAfter  *time.Time `url:"created_at.after,omitempty"`
+
+	// Filters on the date on which the tasks were last modified
+	ModifiedOn       *Date `url:"modified_on,omitempty"`
+	ModifiedOnBefore *Date `url:"modified_on.before,omitempty"`
+	ModifiedOnAfter  *Date `url:"modified_on.after,omitempty"`
+
+	// Filters on the time at which the tasks were last modified
+	ModifiedAtBefore *time.Time `url:"modified_at.before,omitempty"`
+	ModifiedAtAfter  *time.Time `url:"modified_at.after,omitempty"`
+
+	// The field to sort the results on: due_date, created_at, completed_at,
+	// likes or modified_at. Defaults to modified_at.
+	SortBy string `url:"sort_by,omitempty"`
+
+	// Sort the results in ascending order. Defaults to false.
+	SortAscending *bool `url:"sort_ascending,omitempty"`
+}
+
+// Validate checks that the list filters do not contradict each other and
+// that the date filters are consistent
+func (p *SearchParams) Validate() error {
+	conflicts := []struct {
+		name       string
+		match, not []string
+	}{
+		{"assignee", p.AssigneeAny, p.AssigneeNot},
+		{"projects", p.ProjectsAny, p.ProjectsNot},
+		{"projects", p.ProjectsAll, p.ProjectsNot},
+		{"sections", p.SectionsAny, p.SectionsNot},
+		{"sections", p.SectionsAll, p.SectionsNot},
+		{"tags", p.TagsAny, p.TagsNot},
+		{"tags", p.TagsAll, p.TagsNot},
+		{"created_by", p.CreatedByAny, p.CreatedByNot},
+		{"assigned_by", p.AssignedByAny, p.AssignedByNot},
+	}
+	for _, c := range conflicts {
+		for _, id := range c.not {
+			if containsString(c.match, id) {
+				return errors.Errorf("Search both matches and excludes %s %s", c.name, id)
+			}
+		}
+	}
+
+	if err := validateDateFilter("created", p.CreatedOn, p.CreatedOnBefore, p.CreatedOnAfter, p.CreatedAtBefore, p.CreatedAtAfter); err != nil {
+		return err
+	}
+	return validateDateFilter("modified", p.ModifiedOn, p.ModifiedOnBefore, p.ModifiedOnAfter, p.ModifiedAtBefore, p.ModifiedAtAfter)
+}
+
+// validateDateFilter checks that a search property is filtered either on a
+// date or on a time, and that any range is not empty
+func validateDateFilter(name string, on, onBefore, onAfter *Date, atBefore, atAfter *time.Time) error {
+	hasDate := on != nil || onBefore != nil || onAfter != nil
+	hasTime := atBefore != nil || atAfter != nil
+
+	if hasDate && hasTime {
+		return errors.Errorf("Search on %s_on or %s_at, not both", name, name)
+	}
+	if on != nil && (onBefore != nil || onAfter != nil) {
+		return errors.Errorf("Search on %s_on cannot be combined with a %s_on range", name, name)
+	}
+	if onBefore != nil && onAfter != nil && !time.Time(*onAfter).Before(time.Time(*onBefore)) {
+		return errors.Errorf("Search range for %s_on is empty", name)
+	}
+	if atBefore != nil && atAfter != nil && !atAfter.Before(*atBefore) {
+		return errors.Errorf("Search range for %s_at is empty", name)
+	}
+	return nil
+}
+
+// SearchTasks returns the tasks in this workspace matching the search
+// parameters. Search is only available on premium plans, and otherwise
+// fails with a PremiumRequiredError.
+//
+// Search results are not paginated: at most 100 tasks are returned, as set
+// by the Limit option. To see more results, narrow the search, e.g. by
+// sorting on created_at and searching again with CreatedAtBefore set to the
+// oldest result.
+func (w *Workspace) SearchTasks(client *Client, params *SearchParams, opts ...*Options) ([]*Task, error) {
+	client.trace("Searching tasks in workspace %s\n", w.ID)
+
+	if params == nil {
+		params = &SearchParams{}
+	}
+
+	var result []*Task
+	_, err := client.get(fmt.Sprintf("/workspaces/%s/tasks/search", w.ID), params, &result, opts...)
+	return result, err
+}
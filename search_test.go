@@ -0,0 +1,91 @@
+package asana
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func searchQuery(t *testing.T, params *SearchParams) url.Values {
+	t.Helper()
+
+	q := url.Values{}
+	if err := mergeQuery(q, params); err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestSearchParams_DateFilters(t *testing.T) {
+	day := Date(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	q := searchQuery(t, &SearchParams{CreatedOnAfter: &day, ModifiedAtBefore: &at})
+	if q.Get("created_on.after") != "2024-03-01" {
+		t.Errorf("Expected created_on.after=2024-03-01, but saw %q", q.Encode())
+	}
+	if q.Get("modified_at.before") != "2024-03-01T12:30:00Z" {
+		t.Errorf("Expected modified_at.before=2024-03-01T12:30:00Z, but saw %q", q.Encode())
+	}
+	if len(q) != 2 {
+		t.Errorf("Expected only the set filters, but saw %q", q.Encode())
+	}
+}
+
+func TestSearchParams_Validate(t *testing.T) {
+	day := Date(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	later := Date(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC))
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	if err := (&SearchParams{CreatedOnAfter: &day, CreatedOnBefore: &later, ModifiedAtAfter: &at}).Validate(); err != nil {
+		t.Errorf("Expected a valid search, but saw %v", err)
+	}
+
+	invalid := []*SearchParams{
+		{CreatedOnAfter: &day, CreatedAtBefore: &at},
+		{ModifiedOn: &day, ModifiedOnBefore: &later},
+		{CreatedOnAfter: &later, CreatedOnBefore: &day},
+	}
+	for _, params := range invalid {
+		if err := params.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", params)
+		}
+	}
+}
+
+func TestSearchParams_NegationFilters(t *testing.T) {
+	q := searchQuery(t, &SearchParams{
+		AssigneeNot: []string{"me"},
+		ProjectsNot: []string{"111", "222"},
+		TagsNot:     []string{"333"},
+	})
+
+	expected := "assignee.not=me&projects.not=111%2C222&tags.not=333"
+	if q.Encode() != expected {
+		t.Errorf("Expected query %s, but saw %s", expected, q.Encode())
+	}
+}
+
+func TestSearchParams_Combinators(t *testing.T) {
+	tests := []struct {
+		params   *SearchParams
+		expected string
+	}{
+		{&SearchParams{ProjectsAny: []string{"1", "2"}}, "projects.any=1%2C2"},
+		{&SearchParams{TagsAll: []string{"3", "4"}}, "tags.all=3%2C4"},
+		{&SearchParams{SectionsAny: []string{"5"}, SectionsNot: []string{"6"}}, "sections.any=5&sections.not=6"},
+		{&SearchParams{AssigneeAny: []string{"me"}, ProjectsAll: []string{"7"}, TagsNot: []string{"8"}}, "assignee.any=me&projects.all=7&tags.not=8"},
+	}
+	for _, test := range tests {
+		if q := searchQuery(t, test.params); q.Encode() != test.expected {
+			t.Errorf("Expected query %s, but saw %s", test.expected, q.Encode())
+		}
+		if err := test.params.Validate(); err != nil {
+			t.Errorf("Expected %s to be valid, but saw %v", test.expected, err)
+		}
+	}
+
+	if err := (&SearchParams{ProjectsAll: []string{"1"}, ProjectsNot: []string{"1"}}).Validate(); err == nil {
+		t.Error("Expected an error when a project is both required and excluded")
+	}
+}
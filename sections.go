@@ -22,6 +22,9 @@ type Section struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	SectionBase
 
 	// Read-only. The time at which this object was created.
@@ -46,6 +49,16 @@ func (s *Section) Delete(client *Client) error {
 	return err
 }
 
+// DeleteIfExists deletes this section, treating a section which has already been
+// deleted as a success. Unlike Delete, it can be safely repeated.
+func (s *Section) DeleteIfExists(client *Client) error {
+	err := s.Delete(client)
+	if IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
 // Sections returns a list of sections in this project
 func (p *Project) Sections(client *Client, opts ...*Options) ([]*Section, *NextPage, error) {
 	client.trace("Listing sections in %q", p.Name)
@@ -99,3 +112,16 @@ func (s *Section) Update(client *Client, request *UpdateSectionRequest, opts ...
 	err := client.put(fmt.Sprintf("/sections/%s", s.ID), request, result, opts...)
 	return result, err
 }
+
+// GID returns the globally unique ID of this Section
+func (s *Section) GID() string {
+	return s.ID
+}
+
+// Type returns the resource type of this Section
+func (s *Section) Type() string {
+	if s.ResourceType != "" {
+		return s.ResourceType
+	}
+	return "section"
+}
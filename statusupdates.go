@@ -0,0 +1,98 @@
+package asana
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status types for StatusUpdate.StatusType
+const (
+	StatusOnTrack  = "on_track"
+	StatusAtRisk   = "at_risk"
+	StatusOffTrack = "off_track"
+	StatusOnHold   = "on_hold"
+	StatusComplete = "complete"
+)
+
+// StatusUpdate is an update on the progress of a project, portfolio or goal
+type StatusUpdate struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// The subtype of this status update, e.g. project_status_update.
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// The title of the status update.
+	Title string `json:"title,omitempty"`
+
+	// The text content of the status update.
+	Text string `json:"text,omitempty"`
+
+	// The text content of the status update with formatting as HTML.
+	HTMLText string `json:"html_text,omitempty"`
+
+	// The type of the status: on_track, at_risk, off_track, on_hold or
+	// complete.
+	StatusType string `json:"status_type,omitempty"`
+
+	// Read-only. The creator of the status update.
+	Author *User `json:"author,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Read-only. The time at which this object was last modified.
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+}
+
+// StatusUpdateQuery filters the status updates returned by
+// Project.StatusUpdates
+type StatusUpdateQuery struct {
+	// Only return status updates created since this time.
+	CreatedSince *time.Time `url:"created_since,omitempty"`
+}
+
+type statusUpdateParams struct {
+	StatusUpdateQuery
+	Parent string `url:"parent"`
+}
+
+// StatusUpdates returns the status updates posted on this project. The
+// query may be nil to return all status updates.
+func (p *Project) StatusUpdates(client *Client, query *StatusUpdateQuery, opts ...*Options) ([]*StatusUpdate, *NextPage, error) {
+	client.trace("Listing status updates for %q", p.Name)
+
+	params := &statusUpdateParams{Parent: p.ID}
+	if query != nil {
+		params.StatusUpdateQuery = *query
+	}
+
+	var result []*StatusUpdate
+
+	// Make the request
+	nextPage, err := client.get("/status_updates", params, &result, opts...)
+	return result, nextPage, err
+}
+
+// LatestStatusUpdate returns the most recent status update of this project,
+// or nil if no status has been posted. It is read from the
+// current_status_update field of the project, so the full list of updates
+// is not loaded.
+func (p *Project) LatestStatusUpdate(client *Client) (*StatusUpdate, error) {
+	client.trace("Loading latest status update for %q", p.Name)
+
+	result := &struct {
+		CurrentStatusUpdate *StatusUpdate `json:"current_status_update"`
+	}{}
+	_, err := client.get(fmt.Sprintf("/projects/%s", p.ID), nil, result, &Options{
+		Fields: []string{"current_status_update.title", "current_status_update.text", "current_status_update.status_type",
+			"current_status_update.author.name", "current_status_update.created_at"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.CurrentStatusUpdate, nil
+}
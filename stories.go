@@ -2,10 +2,20 @@ package asana
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // StoryBase contains the text of a story, as used when creating a new comment
+//
+// Note: the API does not accept a created_at time for new stories, so
+// comments imported from another tool are always timestamped with the time
+// they were posted, see OriginalCreatedAt. Ordering is preserved when they
+// are posted sequentially with Task.CreateComments, but the original times
+// should be included in the comment text if they need to be kept.
 type StoryBase struct {
 	// Human-readable text for the story or comment. This will
 	// not include the name of the creator. Can be edited only if the story is a comment.
@@ -27,12 +37,27 @@ type StoryBase struct {
 	// Whether the story should be pinned on the resource.
 	// Note: This field is only present on comment and attachment stories.
 	IsPinned bool `json:"is_pinned,omitempty"`
+
+	// The time a comment imported from another tool was originally posted.
+	// This is never sent, as the API ignores client supplied timestamps on
+	// stories: if it is set, CreateComment and CreateComments log a warning
+	// and the comment is timestamped with the time it was posted.
+	OriginalCreatedAt *time.Time `json:"-"`
+}
+
+// warnBackdated logs a warning if a comment was to be backdated, which the
+// API does not support
+func (s *StoryBase) warnBackdated(client *Client) {
+	if s.OriginalCreatedAt != nil {
+		client.warn("The created_at time %s of a comment is ignored by the API, the comment is timestamped with the current time", s.OriginalCreatedAt.Format(time.RFC3339))
+	}
 }
 
 type Dates struct {
 	DueOn   *Date      `json:"due_on,omitempty"`
 	DueAt   *time.Time `json:"due_at,omitempty"`
 	StartOn *Date      `json:"start_on,omitempty"`
+	StartAt *time.Time `json:"start_at,omitempty"`
 }
 
 type StorySubtypeFields struct {
@@ -51,8 +76,8 @@ type StorySubtypeFields struct {
 	NewName string `json:"new_name,omitempty"`
 
 	// Present for resource_subtype_changed
-	OldResourceSubtype string `json:"old_resource_subtype,omitempty"`
-	NewResourceSubtype string `json:"new_resource_subtype,omitempty"`
+	OldResourceSubtype TaskSubtype `json:"old_resource_subtype,omitempty"`
+	NewResourceSubtype TaskSubtype `json:"new_resource_subtype,omitempty"`
 
 	// Present for comment_liked, completion_liked
 	Story *Story `json:"story,omitempty"`
@@ -87,6 +112,18 @@ type StorySubtypeFields struct {
 	OldEnumValue   *EnumValue `json:"old_enum_value,omitempty"`
 	NewEnumValue   *EnumValue `json:"new_enum_value,omitempty"`
 
+	// Present for date_custom_field_changed
+	OldDateValue *DateValue `json:"old_date_value,omitempty"`
+	NewDateValue *DateValue `json:"new_date_value,omitempty"`
+
+	// Present for people_custom_field_changed
+	OldPeopleValue []*User `json:"old_people_value,omitempty"`
+	NewPeopleValue []*User `json:"new_people_value,omitempty"`
+
+	// Present for approval_status_changed
+	OldApprovalStatus string `json:"old_approval_status,omitempty"`
+	NewApprovalStatus string `json:"new_approval_status,omitempty"`
+
 	// Present for duplicate_merged, marked_duplicate, duplicate_unmerged
 	DuplicateOf *Task `json:"duplicate_of,omitempty"`
 
@@ -109,6 +146,9 @@ type Story struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	StoryBase
 
 	// Read-only. The time at which this object was created.
@@ -118,15 +158,22 @@ type Story struct {
 	// Note: This property only exists for stories that provide likes.
 	Liked bool `json:"liked,omitempty"`
 
-	// Read-only. Array of users who have liked this story.
+	// Read-only. Array of likes of this story, each holding the user who
+	// liked it.
 	// Note: This property only exists for stories that provide likes.
-	Likes []*User `json:"likes,omitempty"`
+	//
+	// The API has no separate endpoint for listing likes, they are only
+	// returned inline with the story. Compare the length with NumLikes to
+	// tell whether the full list was loaded.
+	Likes []*Like `json:"likes,omitempty"`
 
 	// Read-only. The number of users who have liked this story.
 	// Note: This property only exists for stories that provide likes.
 	NumLikes int32 `json:"num_likes,omitempty"`
 
-	// The user who created the story.
+	// The user who created the story. This is null for stories generated by
+	// Asana itself rather than by a user, so check IsSystemGenerated before
+	// reading it.
 	CreatedBy *User `json:"created_by,omitempty"`
 
 	// Read-only. The object this story is associated with. Currently may only
@@ -140,12 +187,103 @@ type Story struct {
 	// Read-only. The type of story. This provides fine-grained information about what
 	// triggered the story’s creation. There are many story subtypes, so inspect the
 	// data returned from Asana’s API to find the value for your use case.
-	ResourceSubtype string `json:"resource_subtype,omitempty"`
+	ResourceSubtype StorySubtype `json:"resource_subtype,omitempty"`
 
 	// A union of all possible subtype fields
 	StorySubtypeFields
 }
 
+// Like is a user's like of a task or story
+type Like struct {
+	// Read-only. Globally unique ID of the like
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The user who liked the object.
+	User *User `json:"user,omitempty"`
+}
+
+// IsSystemGenerated returns true if this story has no user as its author,
+// in which case CreatedBy is nil
+func (s *Story) IsSystemGenerated() bool {
+	return s.CreatedBy == nil
+}
+
+// SupportsLikes returns true if this story can be liked, based on its
+// resource_subtype. Only comments, attachments and task completions can be
+// liked; for other stories Liked, Likes and NumLikes are always empty and
+// should not be displayed.
+func (s *Story) SupportsLikes() bool {
+	switch s.ResourceSubtype {
+	case StorySubtypeCommentAdded, StorySubtypeAttachmentAdded, StorySubtypeMarkedComplete:
+		return true
+	}
+	return false
+}
+
+// PermalinkURL returns a link to this story in the Asana web app, which
+// opens its task scrolled to the story. The API has no permalink for
+// stories, so the link is constructed from the link to the task, such as
+// Task.PermalinkURL or the task's permalink_url: in the app's links of the
+// form https://app.asana.com/0/{project}/{task}, the story ID is appended
+// as a further path segment. If taskURL is empty, a link is constructed
+// from the story's target task.
+//
+// This format is not documented by Asana. Task links in any other form are
+// returned unchanged, so that they at least open the task.
+func (s *Story) PermalinkURL(taskURL string) string {
+	if taskURL == "" {
+		if s.Target == nil {
+			return ""
+		}
+		taskURL = fmt.Sprintf("%s/0/0/%s", AppURL, s.Target.ID)
+	}
+
+	u, err := url.Parse(taskURL)
+	if err != nil || !strings.HasPrefix(u.Path, "/0/") {
+		return taskURL
+	}
+
+	// Drop the suffix of links which open the task in full screen
+	path := strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/f")
+	if strings.Count(path, "/") != 3 {
+		return taskURL
+	}
+
+	u.Path = path + "/" + s.ID
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// Fetch loads the full details for this Story
+func (s *Story) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading story details for %q", s.ID)
+
+	_, err := client.get(fmt.Sprintf("/stories/%s", s.ID), nil, s, opts...)
+	return err
+}
+
+// LikedBy returns true if the given user has liked this story. The likes
+// are loaded from the API with the user of each like.
+func (s *Story) LikedBy(client *Client, userGID string) (bool, error) {
+	if s.ResourceSubtype != "" && !s.SupportsLikes() {
+		return false, nil
+	}
+
+	result := &Story{}
+	_, err := client.get(fmt.Sprintf("/stories/%s", s.ID), nil, result, &Options{Fields: []string{"likes.user"}})
+	if err != nil {
+		return false, err
+	}
+
+	for _, like := range result.Likes {
+		if like.User != nil && like.User.ID == userGID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Stories lists all stories attached to a task
 func (t *Task) Stories(client *Client, opts ...*Options) ([]*Story, *NextPage, error) {
 	client.trace("Listing stories for %q", t.Name)
@@ -157,9 +295,58 @@ func (t *Task) Stories(client *Client, opts ...*Options) ([]*Story, *NextPage, e
 	return result, nextPage, err
 }
 
+// StoriesSince returns the stories on a task which were created at or after
+// the given time.
+//
+// Stories are fetched a page at a time. When the API returns stories newest
+// first, paging stops at the first story created before since, avoiding
+// loading the full history of long-lived tasks. The order is detected from
+// the created_at times rather than assumed: the stories endpoint currently
+// returns stories oldest first, in which case every page is still read and
+// only the stories created since the given time are returned.
+func (t *Task) StoriesSince(client *Client, since time.Time, opts ...*Options) ([]*Story, error) {
+	var result []*Story
+
+	var previous *time.Time
+	ascending, descending := false, false
+
+	list := func(opts ...*Options) ([]*Story, *NextPage, error) {
+		return t.Stories(client, opts...)
+	}
+	err := eachPage(client, 100, list, func(stories []*Story) (bool, error) {
+		for _, story := range stories {
+			if story.CreatedAt == nil {
+				continue
+			}
+
+			if previous != nil {
+				if story.CreatedAt.After(*previous) {
+					ascending = true
+				} else if story.CreatedAt.Before(*previous) {
+					descending = true
+				}
+			}
+			previous = story.CreatedAt
+
+			if !story.CreatedAt.Before(since) {
+				result = append(result, story)
+			} else if descending && !ascending {
+				// Every remaining story is older than this one
+				return false, nil
+			}
+		}
+		return true, nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // CreateComment adds a comment story to a task
 func (t *Task) CreateComment(client *Client, story *StoryBase) (*Story, error) {
 	client.info("Creating comment for task %q", t.Name)
+	story.warnBackdated(client)
 
 	result := &Story{}
 
@@ -167,6 +354,33 @@ func (t *Task) CreateComment(client *Client, story *StoryBase) (*Story, error) {
 	return result, err
 }
 
+// CreateComments adds a series of comment stories to a task, in order.
+//
+// The API has no bulk endpoint for stories, so the comments are posted one
+// at a time and each is only posted once the previous one has been created.
+// Rate limited requests are retried, but server errors are not, as the
+// comment may have been created before the error and would be posted twice.
+// Posting stops at the first comment that cannot be created, and the stories
+// created so far are returned along with an error giving the position of
+// the failed comment.
+func (t *Task) CreateComments(client *Client, comments []*StoryBase) ([]*Story, error) {
+	client.info("Creating %d comments for task %q", len(comments), t.Name)
+
+	result := make([]*Story, 0, len(comments))
+	for i, comment := range comments {
+		comment.warnBackdated(client)
+		story := &Story{}
+		err := client.retryRateLimited(func() error {
+			return client.post(fmt.Sprintf("/tasks/%s/stories", t.ID), comment, story)
+		})
+		if err != nil {
+			return result, errors.Wrapf(err, "Create comment %d of %d", i+1, len(comments))
+		}
+		result = append(result, story)
+	}
+	return result, nil
+}
+
 // UpdateStory updates the story and returns the full record for the updated story.
 // Only comment stories can have their text updated, and only comment stories and attachment stories can be pinned.
 // Only one of text and html_text can be specified.
@@ -185,3 +399,26 @@ func (s *Story) Delete(client *Client) error {
 	err := client.delete(fmt.Sprintf("/stories/%s", s.ID))
 	return err
 }
+
+// DeleteIfExists deletes this story, treating a story which has already been
+// deleted as a success. Unlike Delete, it can be safely repeated.
+func (s *Story) DeleteIfExists(client *Client) error {
+	err := s.Delete(client)
+	if IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
+// GID returns the globally unique ID of this Story
+func (s *Story) GID() string {
+	return s.ID
+}
+
+// Type returns the resource type of this Story
+func (s *Story) Type() string {
+	if s.ResourceType != "" {
+		return s.ResourceType
+	}
+	return "story"
+}
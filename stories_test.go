@@ -0,0 +1,140 @@
+package asana
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStory_ApprovalStatusChanged(t *testing.T) {
+	story := &Story{}
+	if err := json.Unmarshal([]byte(`
+{
+	"gid": "1",
+	"resource_type": "story",
+	"resource_subtype": "approval_status_changed",
+	"type": "system",
+	"text": "Someone approved this task",
+	"created_by": {"gid": "10", "name": "Someone"},
+	"old_approval_status": "pending",
+	"new_approval_status": "approved"
+}
+`), story); err != nil {
+		t.Fatal(err)
+	}
+
+	if story.ResourceSubtype != StorySubtypeApprovalStatusChanged {
+		t.Errorf("Expected subtype %q, but saw %q", StorySubtypeApprovalStatusChanged, story.ResourceSubtype)
+	}
+	if story.OldApprovalStatus != ApprovalStatusPending || story.NewApprovalStatus != ApprovalStatusApproved {
+		t.Errorf("Expected approval status to change from pending to approved, but saw %q to %q", story.OldApprovalStatus, story.NewApprovalStatus)
+	}
+}
+
+func TestStory_IsSystemGenerated(t *testing.T) {
+	story := &Story{}
+	if err := json.Unmarshal([]byte(`
+{
+	"gid": "2",
+	"resource_type": "story",
+	"resource_subtype": "due_date_changed",
+	"type": "system",
+	"text": "changed the due date to Mar 8",
+	"created_by": null,
+	"source": "web"
+}
+`), story); err != nil {
+		t.Fatal(err)
+	}
+
+	if !story.IsSystemGenerated() {
+		t.Error("Expected a story without an author to be system generated")
+	}
+
+	story.CreatedBy = &User{ID: "10"}
+	if story.IsSystemGenerated() {
+		t.Error("Expected a story with an author not to be system generated")
+	}
+}
+
+func TestStory_PermalinkURL(t *testing.T) {
+	story := &Story{ID: "3", Target: &Task{ID: "2"}}
+
+	tests := []struct {
+		taskURL  string
+		expected string
+	}{
+		{"https://app.asana.com/0/1/2", "https://app.asana.com/0/1/2/3"},
+		{"https://app.asana.com/0/1/2/f", "https://app.asana.com/0/1/2/3"},
+		{"", "https://app.asana.com/0/0/2/3"},
+		{"https://app.asana.com/1/10/project/1/task/2", "https://app.asana.com/1/10/project/1/task/2"},
+	}
+	for _, test := range tests {
+		if actual := story.PermalinkURL(test.taskURL); actual != test.expected {
+			t.Errorf("Expected %q for %q, but saw %q", test.expected, test.taskURL, actual)
+		}
+	}
+}
+
+func TestTask_CreateComments_DoesNotRetryServerErrors(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Write([]byte(`{"data": {"gid": "1", "text": "First"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"errors": [{"message": "Server error"}]}`))
+	})
+
+	task := &Task{ID: "10"}
+	stories, err := task.CreateComments(client, []*StoryBase{{Text: "First"}, {Text: "Second"}, {Text: "Third"}})
+	if err == nil || !strings.Contains(err.Error(), "comment 2 of 3") {
+		t.Errorf("Expected the second comment to fail, but saw %v", err)
+	}
+	if len(stories) != 1 || stories[0].ID != "1" {
+		t.Errorf("Expected the first story to be returned, but saw %v", stories)
+	}
+	if requests != 2 {
+		t.Errorf("Expected the server error not to be retried, but saw %d requests", requests)
+	}
+}
+
+func TestTask_CreateComment_WarnsWhenBackdated(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if _, ok := body["data"]["created_at"]; ok {
+			t.Error("Expected created_at not to be sent")
+		}
+		w.Write([]byte(`{"data": {"gid": "2"}}`))
+	})
+
+	task := &Task{ID: "1"}
+	if _, err := task.CreateComment(client, &StoryBase{Text: "Hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if logged.Len() != 0 {
+		t.Errorf("Expected no warning, but saw %q", logged.String())
+	}
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if _, err := task.CreateComment(client, &StoryBase{Text: "Hello", OriginalCreatedAt: &createdAt}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logged.String(), "2020-01-02T03:04:05Z") {
+		t.Errorf("Expected a warning about the ignored created_at, but saw %q", logged.String())
+	}
+}
@@ -0,0 +1,119 @@
+package asana
+
+import (
+	"context"
+	"sync"
+)
+
+// maxSubtreeRequests is the number of subtask listings Subtree makes at the
+// same time
+const maxSubtreeRequests = 4
+
+// TaskNode is a task with its subtasks, as loaded by Subtree
+type TaskNode struct {
+	Task     *Task
+	Children []*TaskNode
+}
+
+// Subtree loads the subtasks of this task recursively, down to maxDepth
+// levels below it, see SubtreeContext
+func (t *Task) Subtree(client *Client, maxDepth int) (*TaskNode, error) {
+	return t.SubtreeContext(context.Background(), client, maxDepth)
+}
+
+// SubtreeContext loads the subtasks of this task recursively, down to
+// maxDepth levels below it, for example to export a work breakdown. The
+// subtasks are loaded with their name, completion and number of subtasks,
+// and several tasks are listed at the same time.
+//
+// A task which appears more than once in the tree is only included the
+// first time, so the result is always a tree. No further requests are
+// started once the context is cancelled, and the context's error is
+// returned.
+func (t *Task) SubtreeContext(ctx context.Context, client *Client, maxDepth int) (*TaskNode, error) {
+	loader := &subtreeLoader{
+		ctx:      ctx,
+		client:   client,
+		requests: make(chan struct{}, maxSubtreeRequests),
+		seen:     map[string]bool{t.ID: true},
+	}
+
+	root := &TaskNode{Task: t}
+	loader.load(root, maxDepth)
+	loader.wait.Wait()
+
+	if loader.err != nil {
+		return nil, loader.err
+	}
+	return root, nil
+}
+
+type subtreeLoader struct {
+	ctx      context.Context
+	client   *Client
+	requests chan struct{}
+	wait     sync.WaitGroup
+
+	lock sync.Mutex
+	seen map[string]bool
+	err  error
+}
+
+// load lists the subtasks of a node and starts loading their own subtasks
+func (l *subtreeLoader) load(node *TaskNode, depth int) {
+	if depth <= 0 {
+		return
+	}
+
+	if err := l.ctx.Err(); err != nil {
+		l.fail(err)
+		return
+	}
+
+	l.requests <- struct{}{}
+	subtasks, err := listAll(l.client, 100, func(opts ...*Options) ([]*Task, *NextPage, error) {
+		return node.Task.Subtasks(l.client, opts...)
+	}, &Options{Fields: []string{"name", "completed", "num_subtasks"}})
+	<-l.requests
+	if err != nil {
+		l.fail(err)
+		return
+	}
+
+	l.lock.Lock()
+	for _, subtask := range subtasks {
+		if l.seen[subtask.ID] {
+			continue
+		}
+		l.seen[subtask.ID] = true
+		node.Children = append(node.Children, &TaskNode{Task: subtask})
+	}
+	failed := l.err != nil
+	l.lock.Unlock()
+
+	if failed {
+		return
+	}
+
+	for _, child := range node.Children {
+		if child.Task.NumSubtasks == 0 {
+			continue
+		}
+
+		l.wait.Add(1)
+		go func(child *TaskNode) {
+			defer l.wait.Done()
+			l.load(child, depth-1)
+		}(child)
+	}
+}
+
+// fail records the first error which occurs
+func (l *subtreeLoader) fail(err error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.err == nil {
+		l.err = err
+	}
+}
@@ -0,0 +1,73 @@
+package asana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// subtreeFixture maps task IDs to their subtasks; task 4 lists its
+// ancestor 1 as a subtask to check that cycles are ignored
+var subtreeFixture = map[string][]string{
+	"1": {"2", "3"},
+	"2": {"4"},
+	"3": {},
+	"4": {"1", "5"},
+	"5": {},
+}
+
+func newSubtreeClient(t *testing.T) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/subtasks")
+
+		var items []string
+		for _, subtask := range subtreeFixture[id] {
+			items = append(items, fmt.Sprintf(`{"gid": "%s", "num_subtasks": %d}`, subtask, len(subtreeFixture[subtask])))
+		}
+		fmt.Fprintf(w, `{"data": [%s]}`, strings.Join(items, ","))
+	})
+}
+
+func describeTree(node *TaskNode) string {
+	var children []string
+	for _, child := range node.Children {
+		children = append(children, describeTree(child))
+	}
+	if len(children) == 0 {
+		return node.Task.ID
+	}
+	return node.Task.ID + "(" + strings.Join(children, " ") + ")"
+}
+
+func TestTask_Subtree(t *testing.T) {
+	client := newSubtreeClient(t)
+
+	tree, err := (&Task{ID: "1"}).Subtree(client, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := describeTree(tree); s != "1(2(4(5)) 3)" {
+		t.Errorf("Unexpected tree %s", s)
+	}
+
+	tree, err = (&Task{ID: "1"}).Subtree(client, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := describeTree(tree); s != "1(2 3)" {
+		t.Errorf("Expected the tree to stop at the maximum depth, but saw %s", s)
+	}
+}
+
+func TestTask_SubtreeContext_Cancelled(t *testing.T) {
+	client := newSubtreeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := (&Task{ID: "1"}).SubtreeContext(ctx, client, 10); err != context.Canceled {
+		t.Errorf("Expected the context error, but saw %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package asana
+
+// TaskSubtype is the resource_subtype of a task
+type TaskSubtype string
+
+// Task subtypes for Task.ResourceSubtype
+const (
+	TaskSubtypeDefault   TaskSubtype = "default_task"
+	TaskSubtypeMilestone TaskSubtype = "milestone"
+	TaskSubtypeSection   TaskSubtype = "section"
+	TaskSubtypeApproval  TaskSubtype = "approval"
+)
+
+func (s TaskSubtype) String() string {
+	return string(s)
+}
+
+// AttachmentHost is the service hosting an attachment, given in both the
+// resource_subtype and host fields of an attachment
+type AttachmentHost string
+
+// Attachment hosts for Attachment.ResourceSubtype and Attachment.Host
+const (
+	AttachmentHostAsana    AttachmentHost = "asana"
+	AttachmentHostDropbox  AttachmentHost = "dropbox"
+	AttachmentHostGDrive   AttachmentHost = "gdrive"
+	AttachmentHostOneDrive AttachmentHost = "onedrive"
+	AttachmentHostBox      AttachmentHost = "box"
+	AttachmentHostVimeo    AttachmentHost = "vimeo"
+	AttachmentHostExternal AttachmentHost = "external"
+)
+
+func (h AttachmentHost) String() string {
+	return string(h)
+}
+
+// StorySubtype is the resource_subtype of a story, which describes the
+// change that created it. Asana adds story subtypes from time to time, so a
+// story may have a subtype which is not listed here.
+type StorySubtype string
+
+// Story subtypes for comments and attachments
+const (
+	StorySubtypeCommentAdded    StorySubtype = "comment_added"
+	StorySubtypeCommentLiked    StorySubtype = "comment_liked"
+	StorySubtypeAttachmentAdded StorySubtype = "attachment_added"
+	StorySubtypeAttachmentLiked StorySubtype = "attachment_liked"
+)
+
+// Story subtypes for changes to a task's fields
+const (
+	StorySubtypeAssigned               StorySubtype = "assigned"
+	StorySubtypeUnassigned             StorySubtype = "unassigned"
+	StorySubtypeNameChanged            StorySubtype = "name_changed"
+	StorySubtypeNotesChanged           StorySubtype = "notes_changed"
+	StorySubtypeDueDateChanged         StorySubtype = "due_date_changed"
+	StorySubtypeResourceSubtypeChanged StorySubtype = "resource_subtype_changed"
+	StorySubtypeMarkedComplete         StorySubtype = "marked_complete"
+	StorySubtypeMarkedIncomplete       StorySubtype = "marked_incomplete"
+	StorySubtypeCompletionLiked        StorySubtype = "completion_liked"
+	StorySubtypeLiked                  StorySubtype = "liked"
+	StorySubtypeDuplicated             StorySubtype = "duplicated"
+	StorySubtypeDueToday               StorySubtype = "due_today"
+)
+
+// Story subtypes for changes to a task's projects, sections, tags, parent
+// and followers
+const (
+	StorySubtypeAddedToProject     StorySubtype = "added_to_project"
+	StorySubtypeRemovedFromProject StorySubtype = "removed_from_project"
+	StorySubtypeSectionChanged     StorySubtype = "section_changed"
+	StorySubtypeAddedToTag         StorySubtype = "added_to_tag"
+	StorySubtypeRemovedFromTag     StorySubtype = "removed_from_tag"
+	StorySubtypeAddedToTask        StorySubtype = "added_to_task"
+	StorySubtypeRemovedFromTask    StorySubtype = "removed_from_task"
+	StorySubtypeFollowerAdded      StorySubtype = "follower_added"
+	StorySubtypeCollaboratorAdded  StorySubtype = "collaborator_added"
+)
+
+// Story subtypes for changes to a task's dependencies
+const (
+	StorySubtypeDependencyAdded            StorySubtype = "dependency_added"
+	StorySubtypeDependencyRemoved          StorySubtype = "dependency_removed"
+	StorySubtypeDependencyMarkedComplete   StorySubtype = "dependency_marked_complete"
+	StorySubtypeDependencyMarkedIncomplete StorySubtype = "dependency_marked_incomplete"
+	StorySubtypeDependencyDueDateChanged   StorySubtype = "dependency_due_date_changed"
+	StorySubtypeDependentAdded             StorySubtype = "dependent_added"
+	StorySubtypeDependentRemoved           StorySubtype = "dependent_removed"
+)
+
+// Story subtypes for changes to custom field values
+const (
+	StorySubtypeTextCustomFieldChanged      StorySubtype = "text_custom_field_changed"
+	StorySubtypeNumberCustomFieldChanged    StorySubtype = "number_custom_field_changed"
+	StorySubtypeEnumCustomFieldChanged      StorySubtype = "enum_custom_field_changed"
+	StorySubtypeMultiEnumCustomFieldChanged StorySubtype = "multi_enum_custom_field_changed"
+	StorySubtypeDateCustomFieldChanged      StorySubtype = "date_custom_field_changed"
+	StorySubtypePeopleCustomFieldChanged    StorySubtype = "people_custom_field_changed"
+)
+
+// Story subtypes for approval tasks
+const (
+	StorySubtypeApprovalStatusChanged StorySubtype = "approval_status_changed"
+)
+
+func (s StorySubtype) String() string {
+	return string(s)
+}
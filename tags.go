@@ -3,6 +3,8 @@ package asana
 import (
 	"fmt"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // TagBase contains the modifiable fields for a Tag
@@ -32,6 +34,9 @@ type Tag struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	TagBase
 
 	// Read-only. The time at which this object was created.
@@ -70,27 +75,41 @@ func (w *Workspace) Tags(client *Client, options ...*Options) ([]*Tag, *NextPage
 
 // AllTags repeatedly pages through all available tags in a workspace
 func (w *Workspace) AllTags(client *Client, options ...*Options) ([]*Tag, error) {
-	allTags := []*Tag{}
-	nextPage := &NextPage{}
+	return listAll(client, 50, func(opts ...*Options) ([]*Tag, *NextPage, error) {
+		return w.Tags(client, opts...)
+	}, options...)
+}
 
-	var tags []*Tag
-	var err error
+// TagsByName returns all tags in this workspace with exactly the given name.
+// Asana allows several tags to share a name, so more than one tag may be
+// returned; check for an existing tag here before creating one to avoid
+// duplicates.
+func (w *Workspace) TagsByName(client *Client, name string) ([]*Tag, error) {
+	return w.filterTags(client, func(tag *Tag) bool {
+		return tag.Name == name
+	})
+}
 
-	for nextPage != nil {
-		page := &Options{
-			Limit:  50,
-			Offset: nextPage.Offset,
-		}
+// TagsByColor returns all tags in this workspace with the given color
+func (w *Workspace) TagsByColor(client *Client, color Color) ([]*Tag, error) {
+	return w.filterTags(client, func(tag *Tag) bool {
+		return Color(tag.Color) == color
+	})
+}
 
-		allOptions := append([]*Options{page}, options...)
-		tags, nextPage, err = w.Tags(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+func (w *Workspace) filterTags(client *Client, match func(*Tag) bool) ([]*Tag, error) {
+	tags, err := w.AllTags(client, &Options{Limit: 100, Fields: []string{"name", "color"}})
+	if err != nil {
+		return nil, err
+	}
 
-		allTags = append(allTags, tags...)
+	var result []*Tag
+	for _, tag := range tags {
+		if match(tag) {
+			result = append(result, tag)
+		}
 	}
-	return allTags, nil
+	return result, nil
 }
 
 // CreateTag adds a new tag to a workspace
@@ -106,3 +125,83 @@ func (w *Workspace) CreateTag(client *Client, tag *TagBase, options ...*Options)
 
 	return result, nil
 }
+
+// AddTag adds a tag to this task
+func (t *Task) AddTag(client *Client, tagGID string) error {
+	client.trace("Adding tag %s to task %q", tagGID, t.ID)
+
+	m := map[string]interface{}{
+		"tag": tagGID,
+	}
+	return client.post(fmt.Sprintf("/tasks/%s/addTag", t.ID), m, nil)
+}
+
+// RemoveTag removes a tag from this task
+func (t *Task) RemoveTag(client *Client, tagGID string) error {
+	client.trace("Removing tag %s from task %q", tagGID, t.ID)
+
+	m := map[string]interface{}{
+		"tag": tagGID,
+	}
+	return client.post(fmt.Sprintf("/tasks/%s/removeTag", t.ID), m, nil)
+}
+
+// AddToTasks adds this tag to each of the given tasks and returns the IDs of
+// the tasks which were tagged.
+//
+// The API has no bulk endpoint for tagging, so a request is made for each
+// task, retrying rate limit and server errors. A failure on one task does
+// not stop the others; the errors are returned together as a MultiError.
+func (t *Tag) AddToTasks(client *Client, taskGIDs []string) ([]string, error) {
+	client.info("Adding tag %q to %d tasks", t.Name, len(taskGIDs))
+
+	var succeeded []string
+	errs := &MultiError{}
+	for _, taskGID := range taskGIDs {
+		task := &Task{ID: taskGID}
+		err := client.retry(func() error {
+			return task.AddTag(client, t.ID)
+		})
+		if err != nil {
+			errs.Errors = append(errs.Errors, errors.Wrapf(err, "Add tag to task %s", taskGID))
+			continue
+		}
+		succeeded = append(succeeded, taskGID)
+	}
+	return succeeded, errs.ErrorOrNil()
+}
+
+// RemoveFromTasks removes this tag from each of the given tasks and returns
+// the IDs of the tasks which were untagged. Errors are handled as in
+// AddToTasks.
+func (t *Tag) RemoveFromTasks(client *Client, taskGIDs []string) ([]string, error) {
+	client.info("Removing tag %q from %d tasks", t.Name, len(taskGIDs))
+
+	var succeeded []string
+	errs := &MultiError{}
+	for _, taskGID := range taskGIDs {
+		task := &Task{ID: taskGID}
+		err := client.retry(func() error {
+			return task.RemoveTag(client, t.ID)
+		})
+		if err != nil {
+			errs.Errors = append(errs.Errors, errors.Wrapf(err, "Remove tag from task %s", taskGID))
+			continue
+		}
+		succeeded = append(succeeded, taskGID)
+	}
+	return succeeded, errs.ErrorOrNil()
+}
+
+// GID returns the globally unique ID of this Tag
+func (t *Tag) GID() string {
+	return t.ID
+}
+
+// Type returns the resource type of this Tag
+func (t *Tag) Type() string {
+	if t.ResourceType != "" {
+		return t.ResourceType
+	}
+	return "tag"
+}
@@ -3,6 +3,8 @@ package asana
 import (
 	"fmt"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // TaskQuery specifies which tasks to return from QueryTasks
@@ -91,7 +93,7 @@ type TaskBase struct {
 	// The type of task. Different subtypes of tasks retain many of
 	// the same fields and behavior, but may render differently in Asana or
 	// represent tasks with different semantic meaning.
-	ResourceSubtype string `json:"resource_subtype,omitempty"`
+	ResourceSubtype TaskSubtype `json:"resource_subtype,omitempty"`
 
 	// More detailed, free-form textual information associated with the
 	// task.
@@ -104,9 +106,14 @@ type TaskBase struct {
 	// field can only be set if the assignee is non-null.
 	AssigneeStatus string `json:"assignee_status,omitempty"`
 
-	// True if the task is currently marked complete, false if not.
+	// True if the task is currently marked complete, false if not. This is a
+	// pointer so that an update can send false to reopen a task.
 	Completed *bool `json:"completed,omitempty"`
 
+	// The approval status of the task, for tasks with the approval subtype:
+	// pending, approved, rejected or changes_requested.
+	ApprovalStatus string `json:"approval_status,omitempty"`
+
 	// Date on which this task is due, or null if the task has no due date.
 	// This takes a date with YYYY-MM-DD format and should not be used
 	// together with due_at.
@@ -123,6 +130,13 @@ type TaskBase struct {
 	// unsetting the start_on parameter.
 	StartOn *Date `json:"start_on,omitempty"`
 
+	// Date and time on which work begins for the task, or null if the task
+	// has no start time. This takes a UTC timestamp and should not be used
+	// together with start_on.
+	// Note: due_at must be present in the request when setting or unsetting
+	// the start_at parameter.
+	StartAt *time.Time `json:"start_at,omitempty"`
+
 	// Oauth Required. The external field allows you to store app-specific
 	// metadata on tasks, including an id that can be used to retrieve tasks
 	// and a data blob that can store app-specific character strings. Note
@@ -133,36 +147,99 @@ type TaskBase struct {
 	// Data for more details.
 	External *ExternalData `json:"external,omitempty"`
 
-	// Indicates whether a default task is rendered as bolded and underlined
-	// when viewed in a list of subtasks or in a user’s My Tasks.
-	// Requires that the NewSections deprecation is enabled.
+	// Read-only. Indicates whether a default task is rendered as bolded and
+	// underlined when viewed in a list of subtasks or in a user’s My Tasks.
+	// Requires that the NewSections deprecation is enabled. It is not sent
+	// when creating or updating a task.
 	IsRenderedAsSeparator bool `json:"is_rendered_as_separator,omitempty"`
 }
 
 // Validate checks the task data and fixes any problems
 func (t *CreateTaskRequest) Validate() error {
+	if t.Workspace == "" && len(t.Projects) == 0 && len(t.Memberships) == 0 && t.Parent == "" {
+		return errors.New("A workspace, projects, memberships or a parent task must be specified when creating a task")
+	}
+
+	for _, follower := range t.Followers {
+		if follower == "" {
+			return errors.New("Followers must not contain an empty user")
+		}
+	}
+
+	for _, tag := range t.Tags {
+		if tag == "" {
+			return errors.New("Tags must not contain an empty tag ID")
+		}
+	}
+
 	if t.Assignee == "" {
 		t.AssigneeStatus = ""
 	}
 
+	t.TaskBase.clearReadOnly()
+	t.TaskBase.fixDates()
+	return nil
+}
+
+// Validate checks the task data and fixes any problems
+func (t *UpdateTaskRequest) Validate() error {
+	t.TaskBase.clearReadOnly()
+	t.TaskBase.fixDates()
+	return nil
+}
+
+// clearReadOnly drops the read-only fields, which cannot be written in create
+// and update requests
+func (t *TaskBase) clearReadOnly() {
+	t.IsRenderedAsSeparator = false
+}
+
+// fixDates drops the date fields which are superseded by a time, as the API
+// rejects requests with both
+func (t *TaskBase) fixDates() {
 	if t.DueAt != nil {
 		t.DueOn = nil
 	}
-	return nil
+	if t.StartAt != nil {
+		t.StartOn = nil
+	}
 }
 
 // CreateTaskRequest represents a request to create a new Task
+//
+// Note: created_at, modified_at and completed_at are always set by the API.
+// They cannot be supplied when importing tasks from another tool, so any
+// original timestamps should be preserved elsewhere, e.g. in the notes or in
+// a custom field.
 type CreateTaskRequest struct {
 	TaskBase
 
-	Assignee  string   `json:"assignee,omitempty"`  // User to which this task is assigned, or null if the task is unassigned.
-	Followers []string `json:"followers,omitempty"` // Array of users following this task.
+	Assignee string `json:"assignee,omitempty"` // User to which this task is assigned, or null if the task is unassigned.
+
+	// Users to add as followers of the new task, given as user IDs, email
+	// addresses or "me". Followers are notified of the task as soon as it is
+	// created, without a separate AddFollowers request. The users must be
+	// members of the workspace, which the API checks when the task is created.
+	Followers []string `json:"followers,omitempty"`
+
+	// The workspace to create the task in. May be omitted if projects,
+	// memberships or a parent task are given, as the task is then created in
+	// their workspace.
+	Workspace string `json:"workspace,omitempty"`
+
+	Parent string `json:"parent,omitempty"`
+
+	// The projects to add the task to. A task can be created in several
+	// projects at once, which must all be in the same workspace.
+	Projects []string `json:"projects,omitempty"`
+
+	Memberships []*CreateMembership `json:"memberships,omitempty"`
+
+	// The IDs of tags to add to the new task, saving an AddTag request for
+	// each. The tags must be in the same workspace as the task, which the API
+	// checks when the task is created.
+	Tags []string `json:"tags,omitempty"`
 
-	Workspace    string                 `json:"workspace,omitempty"`
-	Parent       string                 `json:"parent,omitempty"`
-	Projects     []string               `json:"projects,omitempty"`
-	Memberships  []*CreateMembership    `json:"memberships,omitempty"`
-	Tags         []string               `json:"tags,omitempty"`
 	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
@@ -174,9 +251,25 @@ type CreateMembership struct {
 type UpdateTaskRequest struct {
 	TaskBase
 
-	Assignee     string                 `json:"assignee,omitempty"`  // User to which this task is assigned, or null if the task is unassigned.
+	Assignee     string                 `json:"assignee,omitempty"`  // User to which this task is assigned, see SetAssignee to unassign a task.
 	Followers    []string               `json:"followers,omitempty"` // Array of users following this task.
 	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
+	// The ID of the section of the assignee's My Tasks list to move this task
+	// to, in organizations which have migrated to the new My Tasks
+	AssigneeSection *Optional[string] `json:"assignee_section,omitempty"`
+}
+
+// taskAssigneeRequest sets or clears the assignee of a task, which cannot be
+// done through UpdateTaskRequest as it omits an empty assignee
+type taskAssigneeRequest struct {
+	Assignee *Optional[string] `json:"assignee,omitempty"`
+}
+
+// taskDatesRequest sets or clears the start and due dates of a task
+type taskDatesRequest struct {
+	StartOn *Optional[Date] `json:"start_on,omitempty"`
+	DueOn   *Optional[Date] `json:"due_on,omitempty"`
 }
 
 // Task is the basic object around which many operations in Asana are
@@ -198,9 +291,13 @@ type Task struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	TaskBase
 
-	// Read-only. The task this object is attached to.
+	// Read-only. The parent of this task, or null if this is not a subtask.
+	// See FetchParent.
 	Parent *Task `json:"parent,omitempty"`
 
 	// Read-only. The time at which this object was created.
@@ -218,35 +315,59 @@ type Task struct {
 	// This attribute can only be specified at creation time.
 	Workspace *Workspace `json:"workspace,omitempty"`
 
-	// True if the task is liked by the authorized user, false if not.
+	// Read-only. True if the task is liked by the authorized user, false if
+	// not. The liked, likes and num_likes fields are not included in compact
+	// task listings, so they must be requested with the Fields option, e.g.
+	// "liked", "likes.user.name" or "num_likes".
 	Liked bool `json:"liked,omitempty"`
 
-	// Read-only. Array of users who have liked this task.
-	Likes []*User `json:"likes,omitempty"`
+	// Read-only. Array of likes of this task, each holding the user who
+	// liked it.
+	Likes []*Like `json:"likes,omitempty"`
 
 	// Read-only. The number of users who have liked this task.
 	NumLikes int32 `json:"num_likes,omitempty"`
 
+	// Deprecated: hearts were renamed to likes, and these fields are only
+	// returned by the API for older integrations. They hold the same
+	// information as Liked, Likes and NumLikes; use ReactionCount to read
+	// whichever is present. Like likes, hearts hold the user who hearted the
+	// task in their user field.
+	Hearted   bool    `json:"hearted,omitempty"`
+	Hearts    []*Like `json:"hearts,omitempty"`
+	NumHearts int32   `json:"num_hearts,omitempty"`
+
 	// Read-only. Opt In. The number of subtasks on this task.
 	NumSubtasks int32 `json:"num_subtasks,omitempty"`
 
-	// Read-only. Array of users following this task. Followers are a
-	// subset of members who receive all notifications for a project, the
-	// default notification setting when adding members to a project in-
-	// product.
+	// Read-only. Array of users following this task. Followers receive
+	// notifications of changes to the task, and are the collaborators of
+	// the task in the UI. Use AddFollowers and RemoveFollowers to change them.
 	Followers []*User `json:"followers,omitempty"`
 
 	// User to which this task is assigned, or null if the task is unassigned.
+	// A task has at most one assignee, others involved in the task should be
+	// added as followers.
 	Assignee *User `json:"assignee,omitempty"`
 
 	// Scheduling status of this task for the user it is assigned to. This
 	// field can only be set if the assignee is non-null.
 	AssigneeStatus string `json:"assignee_status,omitempty"`
 
+	// The section of the assignee's My Tasks list which contains this task.
+	// This replaces assignee_status in organizations which have migrated to
+	// the new My Tasks.
+	AssigneeSection *Section `json:"assignee_section,omitempty"`
+
 	// Read-only. The time at which this task was completed, or null if the
 	// task is incomplete.
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 
+	// Read-only. Opt In. The user who completed this task, or null if the
+	// task is incomplete. Only returned when requested with opt_fields, e.g.
+	// "completed_by.name".
+	CompletedBy *User `json:"completed_by,omitempty"`
+
 	// Array of custom fields applied to the task. These custom fields
 	// represent the values recorded on this task for a particular custom
 	// field. For example, these fields will contain an enum_value property
@@ -269,6 +390,10 @@ type Task struct {
 	// can be modified using the addProject and removeProject endpoints. Note
 	// that over time, more types of memberships may be added to this
 	// property.
+	//
+	// When listing tasks, request the "memberships.project.name" and
+	// "memberships.section.name" fields to load the section of each task in
+	// the same request, see SectionIn.
 	Memberships []*Membership `json:"memberships,omitempty"`
 
 	// Create-only. Array of tags associated with this task. This property may
@@ -283,6 +408,40 @@ type Task struct {
 	// Read-only. Array of resources referencing tasks that depend on this task.
 	// The objects contain only the ID of the dependent.
 	Dependents []*Task `json:"dependents,omitempty"`
+
+	// Read-only. Opt In. A url that points directly to the object within Asana.
+	Permalink string `json:"permalink_url,omitempty"`
+}
+
+// IsSeparator returns true if this task is displayed as a separator between
+// the tasks of a list, rather than as a task: either a default task rendered
+// as a separator, or a section task of a project which does not use the
+// NewSections API. Request the is_rendered_as_separator and
+// resource_subtype fields to tell them apart from other tasks.
+func (t *Task) IsSeparator() bool {
+	return t.IsRenderedAsSeparator || t.ResourceSubtype == TaskSubtypeSection
+}
+
+// PermalinkURL returns a link to this task in the Asana web app. The
+// permalink_url field is used if it was requested when loading the task,
+// otherwise a link is constructed within the first known project of the task.
+func (t *Task) PermalinkURL() string {
+	if t.Permalink != "" {
+		return t.Permalink
+	}
+
+	project := "0"
+	if len(t.Projects) > 0 {
+		project = t.Projects[0].ID
+	} else {
+		for _, membership := range t.Memberships {
+			if membership.Project != nil {
+				project = membership.Project.ID
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s/0/%s/%s", AppURL, project, t.ID)
 }
 
 // Fetch loads the full details for this Task
@@ -301,12 +460,208 @@ func (t *Task) Update(client *Client, update *UpdateTaskRequest) error {
 	return err
 }
 
+// Complete marks this task as complete
+func (t *Task) Complete(client *Client) error {
+	client.trace("Completing task %q", t.Name)
+
+	return t.Update(client, completedRequest(true))
+}
+
+// Incomplete marks this task as incomplete, reopening a completed task
+func (t *Task) Incomplete(client *Client) error {
+	client.trace("Reopening task %q", t.Name)
+
+	return t.Update(client, completedRequest(false))
+}
+
+func completedRequest(completed bool) *UpdateTaskRequest {
+	update := &UpdateTaskRequest{}
+	update.Completed = Bool(completed)
+	return update
+}
+
+// SetAssignee assigns this task to a single user, given as a user ID, email
+// address or "me". An empty user unassigns the task.
+//
+// Tasks cannot have several assignees. To involve more people in a task use
+// AddFollowers, which keeps them notified of changes.
+func (t *Task) SetAssignee(client *Client, user string) error {
+	client.trace("Assigning task %q to %q", t.Name, user)
+
+	// A null assignee unassigns the task
+	request := &taskAssigneeRequest{Assignee: NullValue[string]()}
+	if user != "" {
+		request.Assignee = SetValue(user)
+	}
+
+	return client.put(fmt.Sprintf("/tasks/%s", t.ID), request, t)
+}
+
+// AssignByEmail assigns this task to the workspace member with the given
+// email address
+func (t *Task) AssignByEmail(client *Client, email string) error {
+	client.trace("Assigning task %q to %q", t.Name, email)
+
+	if t.Workspace == nil {
+		result := &Task{}
+		_, err := client.get(fmt.Sprintf("/tasks/%s", t.ID), nil, result, &Options{Fields: []string{"workspace"}})
+		if err != nil {
+			return err
+		}
+		t.Workspace = result.Workspace
+	}
+
+	assignee, err := t.Workspace.UserIDByEmail(client, email)
+	if err != nil {
+		return err
+	}
+
+	return t.SetAssignee(client, assignee)
+}
+
+// Approval statuses for TaskBase.ApprovalStatus
+const (
+	ApprovalStatusPending          = "pending"
+	ApprovalStatusApproved         = "approved"
+	ApprovalStatusRejected         = "rejected"
+	ApprovalStatusChangesRequested = "changes_requested"
+)
+
+// SetStartAt sets the date and time at which work on this task begins. The
+// API requires the due time to be sent with the start time, so the task must
+// already have a due time loaded.
+func (t *Task) SetStartAt(client *Client, startAt time.Time) error {
+	client.trace("Setting start time of task %q", t.Name)
+
+	if t.DueAt == nil {
+		return errors.Errorf("Task %s must have a due time to set a start time", t.ID)
+	}
+
+	update := &UpdateTaskRequest{}
+	update.StartAt = &startAt
+	update.DueAt = t.DueAt
+	return t.Update(client, update)
+}
+
+// SetDates sets the start and due dates of this task. The API only accepts
+// a start date together with a due date on or after it, so an error is
+// returned without updating the task if start is given without due, or is
+// after it. A nil start with a due date clears the start date, and passing
+// nil for both clears both dates. Any start and due times are replaced.
+func (t *Task) SetDates(client *Client, start, due *Date) error {
+	client.trace("Setting dates of task %q", t.Name)
+
+	if start != nil && due == nil {
+		return errors.Errorf("Task %s must have a due date to set a start date", t.ID)
+	}
+	if start != nil && time.Time(*start).After(time.Time(*due)) {
+		return errors.Errorf("The start date of task %s must not be after its due date", t.ID)
+	}
+
+	request := &taskDatesRequest{
+		StartOn: optionalOrNull(start),
+		DueOn:   optionalOrNull(due),
+	}
+	return client.put(fmt.Sprintf("/tasks/%s", t.ID), request, t)
+}
+
+// Legacy scheduling statuses for Task.AssigneeStatus
+const (
+	AssigneeStatusInbox    = "inbox"
+	AssigneeStatusToday    = "today"
+	AssigneeStatusUpcoming = "upcoming"
+	AssigneeStatusLater    = "later"
+)
+
+// assigneeStatusSections maps legacy assignee statuses to the names of the
+// default sections in the new My Tasks
+var assigneeStatusSections = map[string]string{
+	AssigneeStatusInbox:    "Recently assigned",
+	AssigneeStatusToday:    "Do today",
+	AssigneeStatusUpcoming: "Do next week",
+	AssigneeStatusLater:    "Do later",
+}
+
+// SetAssigneeStatus sets the legacy scheduling status of this task in the
+// assignee's My Tasks list. The task must have an assignee.
+//
+// Organizations which have migrated to the new My Tasks ignore
+// assignee_status. When the status is not applied, the task is instead moved
+// to the matching default section of the assignee's My Tasks list, and a
+// warning is logged. If that section has been renamed or removed an error is
+// returned, and UpdateTaskRequest.AssigneeSection should be set directly. An
+// error is also returned if the task has no assignee.
+func (t *Task) SetAssigneeStatus(client *Client, status string) error {
+	client.trace("Setting assignee status of task %q to %q", t.Name, status)
+
+	sectionName, ok := assigneeStatusSections[status]
+	if !ok {
+		return errors.Errorf("Invalid assignee status %q", status)
+	}
+
+	update := &UpdateTaskRequest{}
+	update.AssigneeStatus = status
+	if err := t.Update(client, update); err != nil {
+		return err
+	}
+
+	if t.AssigneeStatus == status {
+		return nil
+	}
+
+	if t.Assignee == nil || t.Workspace == nil {
+		loaded := &Task{}
+		_, err := client.get(fmt.Sprintf("/tasks/%s", t.ID), nil, loaded, &Options{Fields: []string{"assignee", "workspace"}})
+		if err != nil {
+			return err
+		}
+		t.Assignee, t.Workspace = loaded.Assignee, loaded.Workspace
+	}
+	if t.Assignee == nil {
+		return errors.Errorf("Task %s has no assignee, so its assignee status cannot be set", t.ID)
+	}
+	if t.Workspace == nil {
+		return errors.Errorf("Task %s has no workspace", t.ID)
+	}
+
+	client.warn("assignee_status was not applied to task %s, the workspace appears to use the new My Tasks", t.ID)
+
+	// Find the matching section of the assignee's My Tasks list
+	userTaskList := &Project{}
+	_, err := client.get(fmt.Sprintf("/users/%s/user_task_list", t.Assignee.ID), nil, userTaskList, &Options{Workspace: t.Workspace.ID})
+	if err != nil {
+		return err
+	}
+
+	sections, _, err := userTaskList.Sections(client, &Options{Limit: 100})
+	if err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		if section.Name == sectionName {
+			return t.Update(client, &UpdateTaskRequest{AssigneeSection: SetValue(section.ID)})
+		}
+	}
+	return errors.Errorf("No section named %q in the My Tasks list of user %s", sectionName, t.Assignee.ID)
+}
+
 func (t *Task) Delete(client *Client) error {
 	client.info("Deleting task %q", t.Name)
 
 	return client.delete(fmt.Sprintf("/tasks/%s", t.ID))
 }
 
+// DeleteIfExists deletes this task, treating a task which has already been
+// deleted as a success. Unlike Delete, it can be safely repeated.
+func (t *Task) DeleteIfExists(client *Client) error {
+	err := t.Delete(client)
+	if IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
 // AddProjectRequest defines the location a task should be added to a project
 type AddProjectRequest struct {
 	Project      string // Required: The project to add the task to.
@@ -356,6 +711,34 @@ func (t *Task) RemoveProject(client *Client, projectID string) error {
 	return err
 }
 
+// MoveTo moves this task from one project to another, optionally into a
+// section of the new project. If fromProject and toProject are the same, the
+// task is moved between sections of that project.
+//
+// The task is added to the new project before it is removed from the old
+// one, so a failure never leaves it in neither project. If it cannot be
+// removed from the old project, the returned error reports that the task is
+// now in both.
+func (t *Task) MoveTo(client *Client, fromProject, toProject, toSection string) error {
+	client.trace("Moving task %q from project %q to project %q", t.ID, fromProject, toProject)
+
+	if err := t.AddProject(client, &AddProjectRequest{
+		Project: toProject,
+		Section: toSection,
+	}); err != nil {
+		return errors.Wrapf(err, "Add task %s to project %s", t.ID, toProject)
+	}
+
+	if fromProject == "" || fromProject == toProject {
+		return nil
+	}
+
+	if err := t.RemoveProject(client, fromProject); err != nil {
+		return errors.Wrapf(err, "Task %s was added to project %s but could not be removed from project %s", t.ID, toProject, fromProject)
+	}
+	return nil
+}
+
 // SetParentRequest changes the parent of a task. Each task may only be a subtask of a single parent, or no parent task at all.
 // When using insert_before and insert_after, at most one of those two options can be specified, and they must already be subtasks of the parent.
 type SetParentRequest struct {
@@ -394,12 +777,12 @@ type AddDependenciesRequest struct {
 }
 
 // AddDependencies marks a set of tasks as dependencies of this task, if they
-// are not already dependencies. A task can have at most 15 dependencies.
+// are not already dependencies. A task can have at most 15 dependencies, so
+// an error is returned without making a request if more are given, or none.
 func (t *Task) AddDependencies(client *Client, request *AddDependenciesRequest) error {
 	client.trace("Adding dependencies to task %q", t.ID)
 
-	err := client.post(fmt.Sprintf("/tasks/%s/addDependencies", t.ID), request, nil)
-	return err
+	return client.post(fmt.Sprintf("/tasks/%s/addDependencies", t.ID), request, nil)
 }
 
 // AddDependentsRequest
@@ -409,12 +792,52 @@ type AddDependentsRequest struct {
 }
 
 // AddDependents marks a set of tasks as dependents of this task, if they
-// are not already dependents. A task can have at most 30 dependents.
+// are not already dependents. A task can have at most 30 dependents, so an
+// error is returned without making a request if more are given, or none.
 func (t *Task) AddDependents(client *Client, request *AddDependentsRequest) error {
 	client.trace("Adding dependents to task %q", t.ID)
 
-	err := client.post(fmt.Sprintf("/tasks/%s/addDependents", t.ID), request, nil)
-	return err
+	return client.post(fmt.Sprintf("/tasks/%s/addDependents", t.ID), request, nil)
+}
+
+// Validate checks that at least one and at most 15 dependencies are given
+func (r *AddDependenciesRequest) Validate() error {
+	return validateDependencies("dependencies", r.Dependencies, maxDependencies)
+}
+
+// Validate checks that at least one and at most 30 dependents are given
+func (r *AddDependentsRequest) Validate() error {
+	return validateDependencies("dependents", r.Dependents, maxDependents)
+}
+
+// validateDependencies checks that a list of dependencies or dependents is
+// not empty and within the limit for a task
+func validateDependencies(field string, ids []string, max int) error {
+	if len(ids) == 0 {
+		return errors.Errorf("No %s given", field)
+	}
+	if len(ids) > max {
+		return errors.Errorf("A task can have at most %d %s, but %d were given", max, field, len(ids))
+	}
+	return nil
+}
+
+// AddFollowers adds users to the followers of this task. Followers may be
+// given as user IDs, email addresses or "me".
+//
+// Long lists are sent in several requests, and the errors of any which fail
+// are returned as a MultiError.
+func (t *Task) AddFollowers(client *Client, followers []string) error {
+	client.trace("Adding followers to task %q", t.ID)
+
+	return client.postChunked(fmt.Sprintf("/tasks/%s/addFollowers", t.ID), "followers", followers, maxIDsPerRequest, t)
+}
+
+// RemoveFollowers removes users from the followers of this task
+func (t *Task) RemoveFollowers(client *Client, followers []string) error {
+	client.trace("Removing followers from task %q", t.ID)
+
+	return client.postChunked(fmt.Sprintf("/tasks/%s/removeFollowers", t.ID), "followers", followers, maxIDsPerRequest, t)
 }
 
 // Tasks returns a list of tasks in this project
@@ -437,6 +860,46 @@ func (s *Section) Tasks(client *Client, opts ...*Options) ([]*Task, *NextPage, e
 	return result, nextPage, err
 }
 
+// FetchProjects returns the projects this task belongs to. A task can be in
+// several projects at once.
+func (t *Task) FetchProjects(client *Client, opts ...*Options) ([]*Project, *NextPage, error) {
+	client.trace("Listing projects of task %q", t.Name)
+	var result []*Project
+
+	// Make the request
+	nextPage, err := client.get(fmt.Sprintf("/tasks/%s/projects", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// SectionIn returns the section of the given project which contains this
+// task, from the loaded Memberships. The second result is false if the task
+// has no membership of the project, or its memberships were not loaded.
+func (t *Task) SectionIn(projectGID string) (*Section, bool) {
+	for _, membership := range t.Memberships {
+		if membership.Project != nil && membership.Project.ID == projectGID && membership.Section != nil {
+			return membership.Section, true
+		}
+	}
+	return nil, false
+}
+
+// FetchMemberships loads the projects this task belongs to along with the
+// section of each project which contains it, and stores them in Memberships
+func (t *Task) FetchMemberships(client *Client) ([]*Membership, error) {
+	client.trace("Loading memberships of task %q", t.Name)
+
+	result := &Task{}
+	_, err := client.get(fmt.Sprintf("/tasks/%s", t.ID), nil, result, &Options{
+		Fields: []string{"memberships.project.name", "memberships.section.name"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.Memberships = result.Memberships
+	return t.Memberships, nil
+}
+
 // Subtasks returns a list of tasks in this project
 func (t *Task) Subtasks(client *Client, opts ...*Options) ([]*Task, *NextPage, error) {
 	client.trace("Listing subtasks for %q", t.Name)
@@ -458,16 +921,158 @@ func (c *Client) CreateTask(task *CreateTaskRequest) (*Task, error) {
 	return result, err
 }
 
-// CreateSubtask creates a new task as a subtask of this task
+// ReactionCount returns the number of users who have liked this task. The
+// likes fields are used when present, falling back to the deprecated hearts
+// fields for responses which only include those.
+func (t *Task) ReactionCount() int {
+	switch {
+	case t.NumLikes != 0:
+		return int(t.NumLikes)
+	case len(t.Likes) != 0:
+		return len(t.Likes)
+	case t.NumHearts != 0:
+		return int(t.NumHearts)
+	}
+	return len(t.Hearts)
+}
+
+// IsLikedByMe returns true if the authorized user has liked this task.
+// Liked is only populated when it was requested, so a task which is not
+// marked as liked is checked against its likes if they were loaded, and
+// otherwise the liked field is requested from the API. Request the "liked"
+// field when listing tasks to answer this without further requests for the
+// tasks which are liked.
+func (t *Task) IsLikedByMe(client *Client) (bool, error) {
+	if t.Liked {
+		return true, nil
+	}
+
+	if t.Likes != nil {
+		me, err := client.Me()
+		if err != nil {
+			return false, err
+		}
+		for _, like := range t.Likes {
+			if like.User != nil && like.User.ID == me.ID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	result := &Task{}
+	_, err := client.get(fmt.Sprintf("/tasks/%s", t.ID), nil, result, &Options{Fields: []string{"liked"}})
+	if err != nil {
+		return false, err
+	}
+
+	t.Liked = result.Liked
+	return t.Liked, nil
+}
+
+// FetchParent returns the parent of this task, or nil if this is a
+// top-level task. The inline parent is returned if it was loaded with the
+// task, otherwise the parent is requested from the API. The parent is
+// returned in compact form; call Fetch on it to load its other fields.
+func (t *Task) FetchParent(client *Client) (*Task, error) {
+	if t.Parent != nil {
+		return t.Parent, nil
+	}
+
+	client.trace("Loading parent of task %q", t.Name)
+
+	result := &Task{}
+	_, err := client.get(fmt.Sprintf("/tasks/%s", t.ID), nil, result, &Options{Fields: []string{"parent.name"}})
+	if err != nil {
+		return nil, err
+	}
+
+	t.Parent = result.Parent
+	return t.Parent, nil
+}
+
+// CreateSubtask creates a new task as a subtask of this task. Only the
+// writable fields of the provided task are sent.
 func (t *Task) CreateSubtask(client *Client, task *Task) (*Task, error) {
 	client.info("Creating subtask %q", task.Name)
 
 	result := &Task{}
 
-	err := client.post(fmt.Sprintf("/tasks/%s/subtasks", t.ID), task, result)
+	request := task.createRequest()
+	request.Parent = t.ID
+
+	err := client.post(fmt.Sprintf("/tasks/%s/subtasks", t.ID), request, result)
 	return result, err
 }
 
+// createRequest returns the writable fields of a task as a request to create
+// a new task, leaving out read-only fields such as the ID and timestamps
+func (t *Task) createRequest() *CreateTaskRequest {
+	request := &CreateTaskRequest{
+		TaskBase: t.TaskBase,
+	}
+
+	// Task declares its own assignee_status which shadows the TaskBase field
+	if t.AssigneeStatus != "" {
+		request.AssigneeStatus = t.AssigneeStatus
+	}
+	if t.Assignee != nil {
+		request.Assignee = t.Assignee.ID
+	}
+	if t.Workspace != nil {
+		request.Workspace = t.Workspace.ID
+	}
+	for _, follower := range t.Followers {
+		request.Followers = append(request.Followers, follower.ID)
+	}
+	request.clearReadOnly()
+
+	// Projects with a known section are placed through memberships, the
+	// others through projects
+	placed := make(map[string]bool)
+	for _, membership := range t.Memberships {
+		if membership.Project == nil || membership.Section == nil {
+			continue
+		}
+		request.Memberships = append(request.Memberships, &CreateMembership{
+			Project: membership.Project.ID,
+			Section: membership.Section.ID,
+		})
+		placed[membership.Project.ID] = true
+	}
+	for _, project := range t.Projects {
+		if !placed[project.ID] {
+			request.Projects = append(request.Projects, project.ID)
+			placed[project.ID] = true
+		}
+	}
+	for _, membership := range t.Memberships {
+		if membership.Project != nil && !placed[membership.Project.ID] {
+			request.Projects = append(request.Projects, membership.Project.ID)
+			placed[membership.Project.ID] = true
+		}
+	}
+
+	for _, tag := range t.Tags {
+		request.Tags = append(request.Tags, tag.ID)
+	}
+
+	// Custom field values are copied by field ID, leaving out calculated
+	// fields and values which are not set
+	for _, value := range t.CustomFields {
+		if value.IsReadOnly() {
+			continue
+		}
+		if v := value.updateValue(); v != nil {
+			if request.CustomFields == nil {
+				request.CustomFields = make(map[string]interface{})
+			}
+			request.CustomFields[value.ID] = v
+		}
+	}
+	return request
+}
+
 func (t *Task) GetID() string {
 	return t.ID
 }
@@ -481,3 +1086,16 @@ func (c *Client) QueryTasks(query *TaskQuery, opts ...*Options) ([]*Task, *NextP
 	nextPage, err := c.get("/tasks", query, &result, opts...)
 	return result, nextPage, err
 }
+
+// GID returns the globally unique ID of this Task
+func (t *Task) GID() string {
+	return t.ID
+}
+
+// Type returns the resource type of this Task
+func (t *Task) Type() string {
+	if t.ResourceType != "" {
+		return t.ResourceType
+	}
+	return "task"
+}
@@ -0,0 +1,337 @@
+package asana
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTask_CreateRequest_OmitsReadOnlyFields(t *testing.T) {
+	now := time.Now()
+	task := &Task{
+		ID:         "1",
+		TaskBase:   TaskBase{Name: "Subtask", IsRenderedAsSeparator: true},
+		CreatedAt:  &now,
+		ModifiedAt: &now,
+		NumLikes:   3,
+		Assignee:   &User{ID: "10", Name: "Someone"},
+	}
+
+	bs, err := json.Marshal(task.createRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(bs) != `{"name":"Subtask","assignee":"10"}` {
+		t.Errorf("Expected only writable fields, but saw %s", bs)
+	}
+}
+
+func TestTask_CreateRequest_CopiesMembershipsAndCustomFields(t *testing.T) {
+	fixture := `{
+		"gid": "1",
+		"name": "Copied",
+		"projects": [{"gid": "20"}, {"gid": "21"}],
+		"memberships": [
+			{"project": {"gid": "20"}, "section": {"gid": "30"}},
+			{"project": {"gid": "22"}}
+		],
+		"custom_fields": [
+			{"gid": "40", "resource_subtype": "enum", "enum_value": {"gid": "41"}},
+			{"gid": "42", "resource_subtype": "number", "number_value": 3},
+			{"gid": "43", "resource_subtype": "text", "text_value": null},
+			{"gid": "44", "resource_subtype": "number", "number_value": 5, "is_formula_field": true}
+		]
+	}`
+
+	task := &Task{}
+	if err := json.Unmarshal([]byte(fixture), task); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(task.createRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"name":"Copied","projects":["21","22"],"memberships":[{"project":"20","section":"30"}],"custom_fields":{"40":"41","42":3}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, but saw %s", expected, data)
+	}
+}
+
+func TestCreateTaskRequest_Validate_RequiresLocation(t *testing.T) {
+	if err := (&CreateTaskRequest{TaskBase: TaskBase{Name: "Task"}}).Validate(); err == nil {
+		t.Error("Expected a task without a workspace or projects to be invalid")
+	}
+
+	if err := (&CreateTaskRequest{Projects: []string{"1", "2"}}).Validate(); err != nil {
+		t.Errorf("Expected a task in several projects to be valid, but saw %v", err)
+	}
+}
+
+func TestTaskFields_IncludesCompletedBy(t *testing.T) {
+	found := false
+	for _, field := range Fields(Task{}).Fields {
+		if field == "completed_by" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected completed_by in the task fields")
+	}
+
+	task := &Task{}
+	if err := json.Unmarshal([]byte(`{"gid": "1", "completed": true, "completed_by": {"gid": "2", "name": "Alice"}}`), task); err != nil {
+		t.Fatal(err)
+	}
+	if task.CompletedBy == nil || task.CompletedBy.ID != "2" || task.CompletedBy.Name != "Alice" {
+		t.Errorf("Expected completed_by to be decoded, but saw %+v", task.CompletedBy)
+	}
+}
+
+func TestCreateTaskRequest_TagsSerializeAsIDs(t *testing.T) {
+	task := &Task{
+		TaskBase: TaskBase{Name: "Tagged"},
+		Tags:     []*Tag{{ID: "11", TagBase: TagBase{Name: "urgent"}}, {ID: "12"}},
+	}
+
+	data, err := json.Marshal(task.createRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	tags, ok := fields["tags"].([]interface{})
+	if !ok || len(tags) != 2 || tags[0] != "11" || tags[1] != "12" {
+		t.Errorf("Expected tags to be sent as an array of IDs, but saw %s", data)
+	}
+
+	if err := (&CreateTaskRequest{Workspace: "1", Tags: []string{""}}).Validate(); err == nil {
+		t.Error("Expected an error for an empty tag ID")
+	}
+}
+
+func TestTask_Incomplete_SendsFalse(t *testing.T) {
+	var body map[string]map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"data": {"gid": "1", "completed": false}}`))
+	})
+
+	task := &Task{ID: "1", TaskBase: TaskBase{Completed: Bool(true)}}
+	if err := task.Incomplete(client); err != nil {
+		t.Fatal(err)
+	}
+
+	completed, ok := body["data"]["completed"]
+	if !ok || completed != false {
+		t.Errorf("Expected completed to be sent as false, but saw %v", body)
+	}
+	if task.Completed == nil || *task.Completed {
+		t.Errorf("Expected the task to be updated as incomplete")
+	}
+}
+
+func TestTask_IsLikedByMe(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/me":
+			w.Write([]byte(`{"data": {"gid": "100"}}`))
+		case "/tasks/2":
+			w.Write([]byte(`{"data": {"gid": "2", "liked": true}}`))
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+	})
+
+	task := &Task{}
+	if err := json.Unmarshal([]byte(`{"gid": "1", "likes": [{"gid": "50", "user": {"gid": "100"}}], "num_likes": 1}`), task); err != nil {
+		t.Fatal(err)
+	}
+	if len(task.Likes) != 1 || task.Likes[0].ID != "50" || task.Likes[0].User.ID != "100" {
+		t.Fatalf("Expected likes to be decoded with their users, but saw %+v", task.Likes)
+	}
+
+	liked, err := task.IsLikedByMe(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !liked {
+		t.Error("Expected the task to be liked by the users in its likes")
+	}
+
+	task = &Task{ID: "2"}
+	liked, err = task.IsLikedByMe(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !liked || !task.Liked {
+		t.Error("Expected the liked field to be loaded from the API")
+	}
+}
+
+func TestTask_SetDates(t *testing.T) {
+	var bodies []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		bodies = append(bodies, body["data"])
+		w.Write([]byte(`{"data": {"gid": "1"}}`))
+	})
+
+	start := Date(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	due := Date(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC))
+	task := &Task{ID: "1"}
+
+	if err := task.SetDates(client, &start, nil); err == nil {
+		t.Error("Expected an error for a start date without a due date")
+	}
+	if err := task.SetDates(client, &due, &start); err == nil {
+		t.Error("Expected an error for a start date after the due date")
+	}
+	if len(bodies) != 0 {
+		t.Fatalf("Expected invalid dates not to be sent, but saw %v", bodies)
+	}
+
+	if err := task.SetDates(client, &start, &due); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.SetDates(client, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("Expected two updates, but saw %v", bodies)
+	}
+	if bodies[0]["start_on"] != "2024-03-01" || bodies[0]["due_on"] != "2024-03-08" {
+		t.Errorf("Expected both dates to be sent, but saw %v", bodies[0])
+	}
+	startOn, hasStart := bodies[1]["start_on"]
+	dueOn, hasDue := bodies[1]["due_on"]
+	if !hasStart || !hasDue || startOn != nil || dueOn != nil {
+		t.Errorf("Expected both dates to be cleared with null, but saw %v", bodies[1])
+	}
+}
+
+func TestTask_Update_AssigneeSection(t *testing.T) {
+	var bodies []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		bodies = append(bodies, body["data"])
+		w.Write([]byte(`{"data": {"gid": "1"}}`))
+	})
+
+	task := &Task{ID: "1"}
+	if err := task.Update(client, &UpdateTaskRequest{AssigneeSection: SetValue("5")}); err != nil {
+		t.Fatal(err)
+	}
+	update := &UpdateTaskRequest{}
+	update.Name = "Renamed"
+	if err := task.Update(client, update); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("Expected two updates, but saw %v", bodies)
+	}
+	if bodies[0]["assignee_section"] != "5" {
+		t.Errorf("Expected the assignee section to be sent, but saw %v", bodies[0])
+	}
+	if _, ok := bodies[1]["assignee_section"]; ok {
+		t.Errorf("Expected an unset assignee section to be omitted, but saw %v", bodies[1])
+	}
+}
+
+func TestTask_SetAssignee(t *testing.T) {
+	var bodies []map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		bodies = append(bodies, body["data"])
+		w.Write([]byte(`{"data": {"gid": "1"}}`))
+	})
+
+	task := &Task{ID: "1"}
+	if err := task.SetAssignee(client, "me"); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.SetAssignee(client, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("Expected two updates, but saw %v", bodies)
+	}
+	if bodies[0]["assignee"] != "me" {
+		t.Errorf("Expected the assignee to be sent, but saw %v", bodies[0])
+	}
+	if assignee, ok := bodies[1]["assignee"]; !ok || assignee != nil {
+		t.Errorf("Expected the task to be unassigned with null, but saw %v", bodies[1])
+	}
+}
+
+func TestTask_IsSeparator(t *testing.T) {
+	fixture := `[
+		{"gid": "1", "name": "Planning", "resource_subtype": "default_task", "is_rendered_as_separator": true},
+		{"gid": "2", "name": "Write the spec", "resource_subtype": "default_task", "is_rendered_as_separator": false},
+		{"gid": "3", "name": "Later:", "resource_subtype": "section"},
+		{"gid": "4", "name": "Launch", "resource_subtype": "milestone"}
+	]`
+
+	var tasks []*Task
+	if err := json.Unmarshal([]byte(fixture), &tasks); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []bool{true, false, true, false}
+	for i, task := range tasks {
+		if task.IsSeparator() != expected[i] {
+			t.Errorf("Expected IsSeparator of %q to be %v", task.Name, expected[i])
+		}
+	}
+}
+
+func TestUpdateTaskRequest_SendsCompletedFalse(t *testing.T) {
+	data, err := json.Marshal(&UpdateTaskRequest{TaskBase: TaskBase{Completed: Bool(false)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"completed":false}` {
+		t.Errorf("Expected completed false to be sent, but saw %s", data)
+	}
+}
+
+func TestTask_SetAssigneeStatus_RequiresAssignee(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"gid": "1", "assignee": null}}`))
+	})
+
+	task := &Task{ID: "1"}
+	if err := task.SetAssigneeStatus(client, "today"); err == nil {
+		t.Error("Expected an error for a task without an assignee")
+	}
+}
+
+func TestTask_HeartsDecodeAsLikes(t *testing.T) {
+	task := &Task{}
+	if err := json.Unmarshal([]byte(`{"gid": "1", "hearts": [{"gid": "5", "user": {"gid": "10", "name": "Someone"}}]}`), task); err != nil {
+		t.Fatal(err)
+	}
+	if len(task.Hearts) != 1 || task.Hearts[0].ID != "5" || task.Hearts[0].User == nil || task.Hearts[0].User.ID != "10" {
+		t.Errorf("Expected the heart with its user, but saw %+v", task.Hearts)
+	}
+}
@@ -11,6 +11,9 @@ type Team struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	// Read-only. The name of the object.
 	Name string `json:"name,omitempty"`
 
@@ -44,25 +47,20 @@ func (w *Workspace) Teams(client *Client, options ...*Options) ([]*Team, *NextPa
 
 // AllTeams repeatedly pages through all available teams in a workspace
 func (w *Workspace) AllTeams(client *Client, options ...*Options) ([]*Team, error) {
-	var allTeams []*Team
-	nextPage := &NextPage{}
-
-	var teams []*Team
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
+	return listAll(client, 100, func(opts ...*Options) ([]*Team, *NextPage, error) {
+		return w.Teams(client, opts...)
+	}, options...)
+}
 
-		allOptions := append([]*Options{page}, options...)
-		teams, nextPage, err = w.Teams(client, allOptions...)
-		if err != nil {
-			return nil, err
-		}
+// GID returns the globally unique ID of this Team
+func (t *Team) GID() string {
+	return t.ID
+}
 
-		allTeams = append(allTeams, teams...)
+// Type returns the resource type of this Team
+func (t *Team) Type() string {
+	if t.ResourceType != "" {
+		return t.ResourceType
 	}
-	return allTeams, nil
+	return "team"
 }
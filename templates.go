@@ -0,0 +1,151 @@
+package asana
+
+import (
+	"fmt"
+)
+
+// TemplateRole is a placeholder in a project template which is mapped to a
+// real user when the template is instantiated
+type TemplateRole struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The name of the role.
+	Name string `json:"name,omitempty"`
+}
+
+// DateVariable is a date placeholder in a project template, such as the
+// project start or due date, which is set when the template is instantiated
+type DateVariable struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The name of the date variable.
+	Name string `json:"name,omitempty"`
+
+	// Read-only. The description of what the date variable is used for.
+	Description string `json:"description,omitempty"`
+}
+
+// ProjectTemplate represents a template from which new projects can be
+// created, with the same sections, tasks and custom fields.
+type ProjectTemplate struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The name of the object.
+	Name string `json:"name,omitempty"`
+
+	// Free-form textual information associated with the project template.
+	Description string `json:"description,omitempty"`
+
+	// The description of the project template with formatting as HTML.
+	HTMLDescription string `json:"html_description,omitempty"`
+
+	// True if the project template is shared with the whole organization.
+	Public bool `json:"public,omitempty"`
+
+	// Color of the project template.
+	Color string `json:"color,omitempty"`
+
+	// The current owner of the project template, may be null.
+	Owner *User `json:"owner,omitempty"`
+
+	// The team that this project template is shared with.
+	Team *Team `json:"team,omitempty"`
+
+	// Read-only. Array of date variables which must be given a value when
+	// the template is instantiated.
+	RequestedDates []*DateVariable `json:"requested_dates,omitempty"`
+
+	// Read-only. Array of template roles which can be assigned to users when
+	// the template is instantiated.
+	RequestedRoles []*TemplateRole `json:"requested_roles,omitempty"`
+}
+
+// Fetch loads the full details for this ProjectTemplate, including the
+// roles and dates which need to be mapped when instantiating it
+func (t *ProjectTemplate) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading project template details for %q", t.Name)
+
+	_, err := client.get(fmt.Sprintf("/project_templates/%s", t.ID), nil, t, opts...)
+	return err
+}
+
+// ProjectTemplates returns the project templates shared with this team
+func (t *Team) ProjectTemplates(client *Client, opts ...*Options) ([]*ProjectTemplate, *NextPage, error) {
+	client.trace("Listing project templates in %q", t.Name)
+
+	var result []*ProjectTemplate
+
+	// Make the request
+	nextPage, err := client.get(fmt.Sprintf("/teams/%s/project_templates", t.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// RoleAssignment maps a template role to the user who should take that role
+// in the instantiated project
+type RoleAssignment struct {
+	// Required: The ID of the template role.
+	Role string `json:"gid"`
+
+	// Required: The ID of the user to assign to the role.
+	User string `json:"value"`
+}
+
+// DateAssignment gives a value to a date variable of a project template
+type DateAssignment struct {
+	// Required: The ID of the date variable.
+	DateVariable string `json:"gid"`
+
+	// Required: The date to use for the variable.
+	Date *Date `json:"value"`
+}
+
+// InstantiateProjectRequest describes the project to create from a template
+type InstantiateProjectRequest struct {
+	// Required: The name of the new project.
+	Name string `json:"name"`
+
+	// The team to share the new project with. Defaults to the team of the
+	// template.
+	Team string `json:"team,omitempty"`
+
+	// Sets the project to public to its team.
+	Public *bool `json:"public,omitempty"`
+
+	// The values of the date variables of the template.
+	RequestedDates []*DateAssignment `json:"requested_dates,omitempty"`
+
+	// The users to assign to the roles of the template. Templates which
+	// define roles must have each role mapped in order to be instantiated;
+	// Fetch the template to discover its RequestedRoles.
+	RequestedRoles []*RoleAssignment `json:"requested_roles,omitempty"`
+}
+
+// Instantiate creates a new project from this template. The project is
+// created asynchronously, and the returned job can be fetched to follow its
+// progress.
+func (t *ProjectTemplate) Instantiate(client *Client, request *InstantiateProjectRequest) (*Job, error) {
+	client.info("Instantiating project %q from template %q", request.Name, t.Name)
+
+	result := &Job{}
+	err := client.post(fmt.Sprintf("/project_templates/%s/instantiateProject", t.ID), request, result)
+	return result, err
+}
+
+// GID returns the globally unique ID of this ProjectTemplate
+func (t *ProjectTemplate) GID() string {
+	return t.ID
+}
+
+// Type returns the resource type of this ProjectTemplate
+func (t *ProjectTemplate) Type() string {
+	if t.ResourceType != "" {
+		return t.ResourceType
+	}
+	return "project_template"
+}
@@ -0,0 +1,77 @@
+package asana
+
+import (
+	"fmt"
+)
+
+// Time periods for TimePeriod.Period
+const (
+	PeriodFiscalYear = "FY"
+	PeriodH1         = "H1"
+	PeriodH2         = "H2"
+	PeriodQ1         = "Q1"
+	PeriodQ2         = "Q2"
+	PeriodQ3         = "Q3"
+	PeriodQ4         = "Q4"
+)
+
+// TimePeriod is a planning cycle of a workspace, such as a fiscal year or a
+// quarter, to which goals belong
+type TimePeriod struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The name of the time period for display, e.g. "Q1 FY24".
+	DisplayName string `json:"display_name,omitempty"`
+
+	// Read-only. The kind of time period: FY, H1, H2, Q1, Q2, Q3 or Q4.
+	Period string `json:"period,omitempty"`
+
+	// Read-only. The first day of the time period.
+	StartOn *Date `json:"start_on,omitempty"`
+
+	// Read-only. The last day of the time period.
+	EndOn *Date `json:"end_on,omitempty"`
+
+	// Read-only. The time period which contains this one, e.g. the fiscal
+	// year of a quarter.
+	Parent *TimePeriod `json:"parent,omitempty"`
+}
+
+type timePeriodsRequestParams struct {
+	Workspace string `url:"workspace"`
+}
+
+// TimePeriods lists the time periods of this workspace
+func (w *Workspace) TimePeriods(client *Client, opts ...*Options) ([]*TimePeriod, *NextPage, error) {
+	client.trace("Listing time periods in %q", w.Name)
+
+	var result []*TimePeriod
+	query := timePeriodsRequestParams{Workspace: w.ID}
+	nextPage, err := client.get("/time_periods", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// Fetch loads the full details for this TimePeriod
+func (p *TimePeriod) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading time period details for %q", p.DisplayName)
+
+	_, err := client.get(fmt.Sprintf("/time_periods/%s", p.ID), nil, p, opts...)
+	return err
+}
+
+// GID returns the globally unique ID of this TimePeriod
+func (p *TimePeriod) GID() string {
+	return p.ID
+}
+
+// Type returns the resource type of this TimePeriod
+func (p *TimePeriod) Type() string {
+	if p.ResourceType != "" {
+		return p.ResourceType
+	}
+	return "time_period"
+}
@@ -2,6 +2,7 @@ package asana
 
 import (
 	"encoding/json"
+	"net/url"
 	"time"
 )
 
@@ -35,6 +36,13 @@ func (d *Date) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
+// EncodeValues implements the query.Encoder interface, so that dates in
+// query parameters use the same format as in JSON
+func (d Date) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, time.Time(d).Format(dateLayout))
+	return nil
+}
+
 // Validator types have a Validate method which is called before posting the
 // data to the API
 type Validator interface {
@@ -112,6 +120,121 @@ type Options struct {
 	Workspace string `json:"workspace,omitempty" url:"workspace,omitempty"`
 	Owner     string `json:"owner,omitempty" url:"owner,omitempty"`
 
+	// Only return projects whose archived field takes on the given value
+	Archived *bool `json:"-" url:"archived,omitempty"`
+
 	// Request options
 	Debug *bool `json:"-" url:"-"`
+
+	// When set, the paths in Fields are checked against the JSON fields of
+	// the result type before making the request, and a typo such as
+	// "asignee.name" returns an error instead of silently empty fields.
+	StrictFields *bool `json:"-" url:"-"`
+
+	// The maximum duration of a single request, including reading the
+	// response. Applies on top of any Timeout of the HTTPClient, so it can
+	// shorten but not extend it.
+	Timeout time.Duration `json:"-" url:"-"`
+}
+
+// Resource is implemented by every Asana object, allowing objects of
+// different types to be handled uniformly
+type Resource interface {
+	// GID returns the globally unique ID of the object
+	GID() string
+
+	// Type returns the base type of the object, e.g. "task" or "project"
+	Type() string
+}
+
+var (
+	_ Resource = &Attachment{}
+	_ Resource = &CustomField{}
+	_ Resource = &Portfolio{}
+	_ Resource = &Project{}
+	_ Resource = &Section{}
+	_ Resource = &Story{}
+	_ Resource = &Tag{}
+	_ Resource = &Task{}
+	_ Resource = &Team{}
+	_ Resource = &User{}
+	_ Resource = &Workspace{}
+)
+
+// MergeOptions combines several sets of options into one, in order. Methods
+// which accept a variadic list of options merge them with this function,
+// after the client's DefaultOptions.
+//
+// Options given later take precedence over earlier ones for single values:
+// a Limit, Offset, string or boolean option replaces the value of an earlier
+// option when it is set (non-zero for Limit and strings, non-nil for
+// booleans). List options (Fields, Expand, Enable and Disable) are combined,
+// keeping the first occurrence of each value. Nil options are ignored, and
+// none of the provided options are modified.
+func MergeOptions(opts ...*Options) *Options {
+	result := &Options{}
+
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+
+		if o.Pretty != nil {
+			result.Pretty = o.Pretty
+		}
+		if o.Debug != nil {
+			result.Debug = o.Debug
+		}
+		if o.StrictFields != nil {
+			result.StrictFields = o.StrictFields
+		}
+		if o.Timeout != 0 {
+			result.Timeout = o.Timeout
+		}
+		if o.Method != "" {
+			result.Method = o.Method
+		}
+		if o.JSONP != "" {
+			result.JSONP = o.JSONP
+		}
+		if o.Limit != 0 {
+			result.Limit = o.Limit
+		}
+		if o.Offset != "" {
+			result.Offset = o.Offset
+		}
+		if o.Workspace != "" {
+			result.Workspace = o.Workspace
+		}
+		if o.Owner != "" {
+			result.Owner = o.Owner
+		}
+		if o.Archived != nil {
+			result.Archived = o.Archived
+		}
+
+		result.Fields = union(result.Fields, o.Fields)
+		result.Expand = union(result.Expand, o.Expand)
+		result.Enable = union(result.Enable, o.Enable)
+		result.Disable = union(result.Disable, o.Disable)
+	}
+
+	return result
+}
+
+// union appends the values of b which are not already in a
+func union[T comparable](a, b []T) []T {
+	for _, value := range b {
+		found := false
+		for _, existing := range a {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a = append(a, value)
+		}
+	}
+	return a
 }
@@ -0,0 +1,89 @@
+package asana
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestMergeOptions_LaterLimitWins(t *testing.T) {
+	first := &Options{Limit: 100, Offset: "abc"}
+	second := &Options{Limit: 10}
+
+	merged := MergeOptions(first, second)
+	if merged.Limit != 10 {
+		t.Errorf("Expected the later Limit of 10, but saw %d", merged.Limit)
+	}
+	if merged.Offset != "abc" {
+		t.Errorf("Expected the earlier Offset to be kept, but saw %q", merged.Offset)
+	}
+
+	merged = MergeOptions(second, first)
+	if merged.Limit != 100 {
+		t.Errorf("Expected the later Limit of 100, but saw %d", merged.Limit)
+	}
+
+	if first.Limit != 100 || second.Limit != 10 {
+		t.Error("Expected the provided options not to be modified")
+	}
+}
+
+func TestMergeOptions_UnsetValuesDoNotOverride(t *testing.T) {
+	merged := MergeOptions(&Options{Limit: 50, Debug: Bool(true)}, &Options{}, nil)
+
+	if merged.Limit != 50 {
+		t.Errorf("Expected Limit of 50, but saw %d", merged.Limit)
+	}
+	if !IsTrue(merged.Debug) {
+		t.Error("Expected Debug to be kept")
+	}
+
+	merged = MergeOptions(&Options{Debug: Bool(true)}, &Options{Debug: Bool(false)})
+	if merged.Debug == nil || *merged.Debug {
+		t.Error("Expected an explicit false Debug to override")
+	}
+}
+
+func TestMergeOptions_ListsAreCombined(t *testing.T) {
+	merged := MergeOptions(
+		&Options{Fields: []string{"name", "notes"}, Enable: []Feature{StringIDs}},
+		&Options{Fields: []string{"notes", "assignee"}, Enable: []Feature{NewSections, StringIDs}},
+	)
+
+	if expected := []string{"name", "notes", "assignee"}; !reflect.DeepEqual(merged.Fields, expected) {
+		t.Errorf("Expected Fields %v, but saw %v", expected, merged.Fields)
+	}
+	if expected := []Feature{StringIDs, NewSections}; !reflect.DeepEqual(merged.Enable, expected) {
+		t.Errorf("Expected Enable %v, but saw %v", expected, merged.Enable)
+	}
+}
+
+func TestClient_MergeOptions_DefaultsFirst(t *testing.T) {
+	c := &Client{DefaultOptions: Options{Limit: 20, Fields: []string{"name"}}}
+
+	merged := c.mergeOptions(&Options{Limit: 5})
+	if merged.Limit != 5 {
+		t.Errorf("Expected request Limit to override the default, but saw %d", merged.Limit)
+	}
+	if !reflect.DeepEqual(merged.Fields, []string{"name"}) {
+		t.Errorf("Expected default Fields to be kept, but saw %v", merged.Fields)
+	}
+	if c.DefaultOptions.Limit != 20 {
+		t.Error("Expected DefaultOptions not to be modified")
+	}
+}
+
+func TestOptions_ArchivedFilter(t *testing.T) {
+	merged := MergeOptions(&Options{Archived: Bool(true)}, &Options{Archived: Bool(false)}, &Options{})
+	if merged.Archived == nil || *merged.Archived {
+		t.Fatalf("Expected the later Archived filter of false, but saw %v", merged.Archived)
+	}
+
+	q := url.Values{}
+	if err := mergeQuery(q, merged); err != nil {
+		t.Fatal(err)
+	}
+	if q.Get("archived") != "false" {
+		t.Errorf("Expected archived=false in the query, but saw %q", q.Encode())
+	}
+}
@@ -1,6 +1,13 @@
 package asana
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 // User represents an account in Asana that can be given access to various
 // workspaces, projects, and tasks.
@@ -12,6 +19,9 @@ type User struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	// Read-only. The name of the object.
 	Name string `json:"name,omitempty"`
 
@@ -40,6 +50,45 @@ func (c *Client) CurrentUser() (*User, error) {
 	return result, err
 }
 
+// Ping checks that the API is reachable and the client's credentials are
+// valid, by requesting just the ID of the current user. It makes a single
+// request without retries, so it is suitable for readiness probes. If the
+// credentials are rejected the error satisfies IsAuthError.
+func (c *Client) Ping() error {
+	_, err := c.get("/users/me", nil, nil, &Options{Fields: []string{"gid"}})
+	return err
+}
+
+// Me returns the currently authorized user. The user is fetched on the first
+// call and cached for the lifetime of the client, so it is safe to call
+// repeatedly. Use ClearMeCache to discard the cached user.
+func (c *Client) Me() (*User, error) {
+	c.meLock.Lock()
+	defer c.meLock.Unlock()
+
+	if c.me != nil {
+		return c.me, nil
+	}
+
+	user, err := c.CurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	c.me = user
+	return user, nil
+}
+
+// ClearMeCache discards the user cached by Me, so that the next call fetches
+// it again. This is useful for long-lived clients where the user's details
+// may change.
+func (c *Client) ClearMeCache() {
+	c.meLock.Lock()
+	defer c.meLock.Unlock()
+
+	c.me = nil
+}
+
 // Fetch loads the full details for this User
 func (u *User) Fetch(client *Client, options ...*Options) error {
 	client.trace("Loading details for user %q", u.ID)
@@ -61,25 +110,120 @@ func (w *Workspace) Users(client *Client, options ...*Options) ([]*User, *NextPa
 
 // AllUsers repeatedly pages through all available users in a workspace
 func (w *Workspace) AllUsers(client *Client, options ...*Options) ([]*User, error) {
-	var allUsers []*User
-	nextPage := &NextPage{}
+	return listAll(client, 50, func(opts ...*Options) ([]*User, *NextPage, error) {
+		return w.Users(client, opts...)
+	}, options...)
+}
+
+// favoriteTypes maps the resource types which can be favorited to a
+// constructor for their type
+var favoriteTypes = map[string]func() Resource{
+	"portfolio":        func() Resource { return &Portfolio{} },
+	"project":          func() Resource { return &Project{} },
+	"project_template": func() Resource { return &ProjectTemplate{} },
+	"tag":              func() Resource { return &Tag{} },
+	"task":             func() Resource { return &Task{} },
+	"user":             func() Resource { return &User{} },
+}
+
+// Favorites lists the user's favorites of one resource type in a workspace,
+// in the order they appear in the Asana sidebar. The resource type must be
+// one of portfolio, project, project_template, tag, task or user. Each
+// result has the concrete type for its resource, e.g. *Project.
+func (u *User) Favorites(client *Client, resourceType string, workspace string, opts ...*Options) ([]Resource, *NextPage, error) {
+	client.trace("Listing favorite %ss of user %s", resourceType, u.ID)
+
+	newResource, ok := favoriteTypes[resourceType]
+	if !ok {
+		return nil, nil, errors.Errorf("Resource type %q cannot be a favorite", resourceType)
+	}
 
-	var users []*User
-	var err error
+	query := favoritesRequestParams{
+		ResourceType: resourceType,
+		Workspace:    workspace,
+	}
+
+	var raw []json.RawMessage
+	nextPage, err := client.get(fmt.Sprintf("/users/%s/favorites", u.ID), query, &raw, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	for nextPage != nil {
-		page := &Options{
-			Limit:  50,
-			Offset: nextPage.Offset,
+	result := make([]Resource, 0, len(raw))
+	for _, data := range raw {
+		resource := newResource()
+		if err := json.Unmarshal(data, resource); err != nil {
+			return nil, nil, errors.Wrap(err, "Unable to parse favorite")
 		}
+		result = append(result, resource)
+	}
+	return result, nextPage, nil
+}
 
-		allOptions := append([]*Options{page}, options...)
-		users, nextPage, err = w.Users(client, allOptions...)
-		if err != nil {
-			return nil, err
+// GID returns the globally unique ID of this User
+func (u *User) GID() string {
+	return u.ID
+}
+
+// Type returns the resource type of this User
+func (u *User) Type() string {
+	if u.ResourceType != "" {
+		return u.ResourceType
+	}
+	return "user"
+}
+
+// emailCacheDuration is how long the users of a workspace listed by
+// UserIDByEmail are trusted, before an unknown address lists them again
+const emailCacheDuration = 5 * time.Minute
+
+// UserIDByEmail resolves an email address to the ID of a user in this
+// workspace. The users of the workspace are listed on the first lookup and
+// cached on the client, so repeated lookups do not make further requests.
+// An error is returned if no member of the workspace has the address. The
+// users are only listed again for an unknown address once the list is more
+// than five minutes old, so that new members are found eventually.
+func (w *Workspace) UserIDByEmail(client *Client, email string) (string, error) {
+	key := func(email string) string {
+		return w.ID + "/" + strings.ToLower(email)
+	}
+
+	client.emailLock.Lock()
+	id, ok := client.emailIDs[key(email)]
+	listed := client.emailListed[w.ID]
+	client.emailLock.Unlock()
+
+	if ok {
+		return id, nil
+	}
+	if !listed.IsZero() && time.Since(listed) < emailCacheDuration {
+		return "", errors.Errorf("No user with email %q is a member of workspace %s", email, w.ID)
+	}
+
+	// The lock is not held while listing, so that lookups in other
+	// workspaces and of cached addresses are not blocked by the requests
+	client.trace("Resolving user %q in workspace %s", email, w.ID)
+	users, err := w.AllUsers(client, &Options{Fields: []string{"name", "email"}})
+	if err != nil {
+		return "", err
+	}
+
+	client.emailLock.Lock()
+	defer client.emailLock.Unlock()
+
+	if client.emailIDs == nil {
+		client.emailIDs = make(map[string]string)
+		client.emailListed = make(map[string]time.Time)
+	}
+	for _, user := range users {
+		if user.Email != "" {
+			client.emailIDs[key(user.Email)] = user.ID
 		}
+	}
+	client.emailListed[w.ID] = time.Now()
 
-		allUsers = append(allUsers, users...)
+	if id, ok := client.emailIDs[key(email)]; ok {
+		return id, nil
 	}
-	return allUsers, nil
+	return "", errors.Errorf("No user with email %q is a member of workspace %s", email, w.ID)
 }
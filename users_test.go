@@ -0,0 +1,28 @@
+package asana
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWorkspace_UserIDByEmail_CachesMisses(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data": [{"gid": "10", "name": "Someone", "email": "someone@example.com"}]}`))
+	})
+	workspace := &Workspace{ID: "1"}
+
+	if id, err := workspace.UserIDByEmail(client, "Someone@example.com"); err != nil || id != "10" {
+		t.Errorf("Expected user 10, but saw %q, %v", id, err)
+	}
+	if _, err := workspace.UserIDByEmail(client, "nobody@example.com"); err == nil {
+		t.Error("Expected an error for an address which is not a member")
+	}
+	if _, err := workspace.UserIDByEmail(client, "nobody@example.com"); err == nil {
+		t.Error("Expected an error for an address which is not a member")
+	}
+	if requests != 1 {
+		t.Errorf("Expected the users to be listed once, but saw %d requests", requests)
+	}
+}
@@ -0,0 +1,308 @@
+package asana
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Webhook actions for WebhookFilter.Action
+const (
+	WebhookActionAdded     = "added"
+	WebhookActionRemoved   = "removed"
+	WebhookActionChanged   = "changed"
+	WebhookActionDeleted   = "deleted"
+	WebhookActionUndeleted = "undeleted"
+)
+
+// WebhookFilter selects the events delivered to a webhook. An event is
+// delivered if it matches any of the webhook's filters.
+type WebhookFilter struct {
+	// The type of the resource which generated the event, e.g. task or story
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// The subtype of the resource, e.g. milestone or comment_added
+	ResourceSubtype string `json:"resource_subtype,omitempty"`
+
+	// The action of the event: added, removed, changed, deleted or undeleted
+	Action string `json:"action,omitempty"`
+
+	// For changed events, the fields whose changes are delivered
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Validate checks that the filter is a combination accepted by the API:
+// every filter needs a resource type, and fields can only be given for, and
+// are required by, the changed action.
+func (f *WebhookFilter) Validate() error {
+	if f.ResourceType == "" {
+		return errors.New("A webhook filter must have a resource_type")
+	}
+
+	switch f.Action {
+	case WebhookActionChanged:
+		if len(f.Fields) == 0 {
+			return errors.Errorf("A webhook filter for changed %s events must list the fields to watch", f.ResourceType)
+		}
+	case WebhookActionAdded, WebhookActionRemoved, WebhookActionDeleted, WebhookActionUndeleted, "":
+		if len(f.Fields) != 0 {
+			return errors.Errorf("Webhook filter fields are only valid for changed events, not %s", f.Action)
+		}
+	default:
+		return errors.Errorf("Unknown webhook action %q", f.Action)
+	}
+
+	for _, field := range f.Fields {
+		if field == "" {
+			return errors.New("Webhook filter fields must not contain an empty field name")
+		}
+	}
+	return nil
+}
+
+// Webhook delivers events about changes to a resource, and the resources it
+// contains, to a target URL
+type Webhook struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. If true, the webhook will send events; if false it is
+	// considered inactive and will not generate events.
+	Active bool `json:"active,omitempty"`
+
+	// Read-only. The resource the webhook is subscribed to.
+	Resource *WebhookResource `json:"resource,omitempty"`
+
+	// Read-only. The URL to receive the HTTP POST.
+	Target string `json:"target,omitempty"`
+
+	// Read-only. The filters which select the events delivered.
+	Filters []*WebhookFilter `json:"filters,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Read-only. The time at which the most recent delivery failed.
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+
+	// Read-only. The error of the most recent failed delivery.
+	LastFailureContent string `json:"last_failure_content,omitempty"`
+
+	// Read-only. The time at which the most recent delivery succeeded.
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+}
+
+// WebhookResource is the compact form of the resource a webhook is
+// subscribed to, which may be of any type
+type WebhookResource struct {
+	ID           string `json:"gid,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+// CreateWebhookRequest represents a request to create a new webhook
+type CreateWebhookRequest struct {
+	// The ID of the resource to subscribe to
+	Resource string `json:"resource"`
+
+	// The URL to receive the events. The target must complete the
+	// X-Hook-Secret handshake before the webhook is created.
+	Target string `json:"target"`
+
+	// The filters which select the events delivered, see
+	// WebhookFilterBuilder
+	Filters []*WebhookFilter `json:"filters,omitempty"`
+}
+
+// Validate checks the webhook and its filters before they are sent to the
+// API, which rejects incoherent filters without saying which is at fault
+func (r *CreateWebhookRequest) Validate() error {
+	if r.Resource == "" {
+		return errors.New("A webhook must have a resource")
+	}
+	if r.Target == "" {
+		return errors.New("A webhook must have a target URL")
+	}
+
+	for i, filter := range r.Filters {
+		if err := filter.Validate(); err != nil {
+			return errors.Wrapf(err, "Webhook filter %d", i)
+		}
+	}
+	return nil
+}
+
+// CreateWebhook subscribes a target URL to events about a resource. Asana
+// sends a handshake request to the target before responding, so the target
+// must already be serving.
+func (c *Client) CreateWebhook(request *CreateWebhookRequest) (*Webhook, error) {
+	c.info("Creating webhook for %s to %s", request.Resource, request.Target)
+
+	result := &Webhook{}
+	err := c.post("/webhooks", request, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Create webhook")
+	}
+	return result, nil
+}
+
+type webhooksRequestParams struct {
+	Workspace string `url:"workspace"`
+	Resource  string `url:"resource,omitempty"`
+}
+
+// Webhooks lists the webhooks created by the authorized user in a
+// workspace. If resource is not empty, only the webhooks subscribed to that
+// resource are listed.
+func (c *Client) Webhooks(workspace string, resource string, opts ...*Options) ([]*Webhook, *NextPage, error) {
+	c.trace("Listing webhooks in workspace %s", workspace)
+
+	if workspace == "" {
+		return nil, nil, errors.New("A workspace must be specified when listing webhooks")
+	}
+
+	var result []*Webhook
+	query := webhooksRequestParams{
+		Workspace: workspace,
+		Resource:  resource,
+	}
+	nextPage, err := c.get("/webhooks", query, &result, opts...)
+	return result, nextPage, err
+}
+
+// AllWebhooks repeatedly pages through all webhooks in a workspace,
+// optionally only those subscribed to a resource, see Webhooks
+func (c *Client) AllWebhooks(workspace string, resource string, opts ...*Options) ([]*Webhook, error) {
+	return listAll(c, 100, func(opts ...*Options) ([]*Webhook, *NextPage, error) {
+		return c.Webhooks(workspace, resource, opts...)
+	}, opts...)
+}
+
+// Webhooks lists all webhooks subscribed to this task. The task's workspace
+// is loaded first if it is not known.
+func (t *Task) Webhooks(client *Client) ([]*Webhook, error) {
+	if t.Workspace == nil {
+		if err := t.Fetch(client, &Options{Fields: []string{"name", "workspace"}}); err != nil {
+			return nil, err
+		}
+	}
+	return client.AllWebhooks(t.Workspace.ID, t.ID)
+}
+
+// Webhooks lists all webhooks subscribed to this project. The project's
+// workspace is loaded first if it is not known.
+func (p *Project) Webhooks(client *Client) ([]*Webhook, error) {
+	if p.Workspace == nil {
+		if err := p.Fetch(client, &Options{Fields: []string{"name", "workspace"}}); err != nil {
+			return nil, err
+		}
+	}
+	return client.AllWebhooks(p.Workspace.ID, p.ID)
+}
+
+// Fetch loads the full details for this Webhook
+func (w *Webhook) Fetch(client *Client, opts ...*Options) error {
+	client.trace("Loading webhook details for %s", w.ID)
+
+	_, err := client.get(fmt.Sprintf("/webhooks/%s", w.ID), nil, w, opts...)
+	return err
+}
+
+// Delete removes this webhook, which stops delivery of its events
+func (w *Webhook) Delete(client *Client) error {
+	client.info("Deleting webhook %s", w.ID)
+
+	return client.delete(fmt.Sprintf("/webhooks/%s", w.ID))
+}
+
+// GID returns the globally unique ID of this Webhook
+func (w *Webhook) GID() string {
+	return w.ID
+}
+
+// Type returns the resource type of this Webhook
+func (w *Webhook) Type() string {
+	if w.ResourceType != "" {
+		return w.ResourceType
+	}
+	return "webhook"
+}
+
+// WebhookFilterBuilder builds a list of webhook filters. Each action method
+// starts a new filter, which Subtype refines:
+//
+//	filters, err := NewWebhookFilterBuilder().
+//		Changed("task", "name", "completed").
+//		Added("story").Subtype("comment_added").
+//		Build()
+type WebhookFilterBuilder struct {
+	filters []*WebhookFilter
+}
+
+// NewWebhookFilterBuilder returns an empty WebhookFilterBuilder
+func NewWebhookFilterBuilder() *WebhookFilterBuilder {
+	return &WebhookFilterBuilder{}
+}
+
+func (b *WebhookFilterBuilder) add(resourceType, action string, fields []string) *WebhookFilterBuilder {
+	b.filters = append(b.filters, &WebhookFilter{
+		ResourceType: resourceType,
+		Action:       action,
+		Fields:       fields,
+	})
+	return b
+}
+
+// Added adds a filter for resources of the given type being added, e.g. a
+// task added to a project
+func (b *WebhookFilterBuilder) Added(resourceType string) *WebhookFilterBuilder {
+	return b.add(resourceType, WebhookActionAdded, nil)
+}
+
+// Removed adds a filter for resources of the given type being removed
+func (b *WebhookFilterBuilder) Removed(resourceType string) *WebhookFilterBuilder {
+	return b.add(resourceType, WebhookActionRemoved, nil)
+}
+
+// Changed adds a filter for changes to the given fields of resources of
+// the given type
+func (b *WebhookFilterBuilder) Changed(resourceType string, fields ...string) *WebhookFilterBuilder {
+	return b.add(resourceType, WebhookActionChanged, fields)
+}
+
+// Deleted adds a filter for resources of the given type being deleted
+func (b *WebhookFilterBuilder) Deleted(resourceType string) *WebhookFilterBuilder {
+	return b.add(resourceType, WebhookActionDeleted, nil)
+}
+
+// Undeleted adds a filter for resources of the given type being restored
+// after deletion
+func (b *WebhookFilterBuilder) Undeleted(resourceType string) *WebhookFilterBuilder {
+	return b.add(resourceType, WebhookActionUndeleted, nil)
+}
+
+// Subtype restricts the most recently added filter to a resource subtype
+func (b *WebhookFilterBuilder) Subtype(resourceSubtype string) *WebhookFilterBuilder {
+	if len(b.filters) > 0 {
+		b.filters[len(b.filters)-1].ResourceSubtype = resourceSubtype
+	}
+	return b
+}
+
+// Build validates and returns the filters
+func (b *WebhookFilterBuilder) Build() ([]*WebhookFilter, error) {
+	if len(b.filters) == 0 {
+		return nil, errors.New("No webhook filters were added")
+	}
+
+	for i, filter := range b.filters {
+		if err := filter.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "Webhook filter %d", i)
+		}
+	}
+	return b.filters, nil
+}
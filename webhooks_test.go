@@ -0,0 +1,79 @@
+package asana
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWebhookFilterBuilder(t *testing.T) {
+	filters, err := NewWebhookFilterBuilder().
+		Changed("task", "name", "completed").
+		Added("story").Subtype("comment_added").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filters) != 2 {
+		t.Fatalf("Expected two filters, but saw %d", len(filters))
+	}
+	if filters[0].Action != WebhookActionChanged || len(filters[0].Fields) != 2 || filters[0].ResourceSubtype != "" {
+		t.Errorf("Unexpected changed filter %+v", filters[0])
+	}
+	if filters[1].ResourceType != "story" || filters[1].ResourceSubtype != "comment_added" {
+		t.Errorf("Expected the subtype to refine the last filter, but saw %+v", filters[1])
+	}
+}
+
+func TestWebhookFilter_Validate(t *testing.T) {
+	invalid := []*WebhookFilter{
+		{Action: WebhookActionAdded},
+		{ResourceType: "task", Action: WebhookActionChanged},
+		{ResourceType: "task", Action: WebhookActionAdded, Fields: []string{"name"}},
+		{ResourceType: "task", Action: "moved"},
+		{ResourceType: "task", Action: WebhookActionChanged, Fields: []string{""}},
+	}
+	for _, filter := range invalid {
+		if err := filter.Validate(); err == nil {
+			t.Errorf("Expected an error for %+v", filter)
+		}
+	}
+
+	request := &CreateWebhookRequest{
+		Resource: "1",
+		Target:   "https://example.com/hook",
+		Filters:  []*WebhookFilter{{ResourceType: "task", Action: WebhookActionDeleted}},
+	}
+	if err := request.Validate(); err != nil {
+		t.Errorf("Expected a valid webhook, but saw %s", err)
+	}
+
+	request.Filters = append(request.Filters, &WebhookFilter{ResourceType: "task", Action: WebhookActionChanged})
+	if err := request.Validate(); err == nil {
+		t.Error("Expected an error for an incoherent filter")
+	}
+}
+
+func TestTask_Webhooks(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks/1":
+			w.Write([]byte(`{"data": {"gid": "1", "workspace": {"gid": "10"}}}`))
+		case "/webhooks":
+			if r.URL.Query().Get("workspace") != "10" || r.URL.Query().Get("resource") != "1" {
+				t.Errorf("Expected webhooks to be filtered by workspace and resource, but saw %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{"gid": "100", "resource": {"gid": "1", "resource_type": "task"}, "target": "https://example.com/hook"}]}`))
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+		}
+	})
+
+	webhooks, err := (&Task{ID: "1"}).Webhooks(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks) != 1 || webhooks[0].Resource.ID != "1" {
+		t.Errorf("Expected the webhook of the task, but saw %+v", webhooks)
+	}
+}
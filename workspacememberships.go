@@ -0,0 +1,87 @@
+package asana
+
+import (
+	"fmt"
+	"time"
+)
+
+// WorkspaceMembership represents a user's membership of a workspace or
+// organization, and their role in it
+type WorkspaceMembership struct {
+	// Read-only. Globally unique ID of the object
+	ID string `json:"gid,omitempty"`
+
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Read-only. The member.
+	User *User `json:"user,omitempty"`
+
+	// Read-only. The workspace the user is a member of.
+	Workspace *Workspace `json:"workspace,omitempty"`
+
+	// Read-only. Whether the user is an active member of the workspace.
+	// Deactivated users keep their membership but cannot sign in.
+	IsActive bool `json:"is_active,omitempty"`
+
+	// Read-only. Whether the user is an administrator of the workspace.
+	IsAdmin bool `json:"is_admin,omitempty"`
+
+	// Read-only. Whether the user is a guest, with access only to the
+	// objects shared with them.
+	IsGuest bool `json:"is_guest,omitempty"`
+
+	// Read-only. The time at which this object was created.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// WorkspaceMemberships lists the memberships of this workspace
+func (w *Workspace) WorkspaceMemberships(client *Client, opts ...*Options) ([]*WorkspaceMembership, *NextPage, error) {
+	client.trace("Listing memberships of workspace %q", w.Name)
+
+	var result []*WorkspaceMembership
+	nextPage, err := client.get(fmt.Sprintf("/workspaces/%s/workspace_memberships", w.ID), nil, &result, opts...)
+	return result, nextPage, err
+}
+
+// AllWorkspaceMemberships repeatedly pages through all memberships of this
+// workspace
+func (w *Workspace) AllWorkspaceMemberships(client *Client, opts ...*Options) ([]*WorkspaceMembership, error) {
+	return listAll(client, 100, func(opts ...*Options) ([]*WorkspaceMembership, *NextPage, error) {
+		return w.WorkspaceMemberships(client, opts...)
+	}, opts...)
+}
+
+// MembershipsByRole returns the memberships of administrators of this
+// workspace if admins is true, otherwise the memberships of guests. The API
+// cannot filter memberships by role, so all memberships are loaded and
+// filtered here.
+func (w *Workspace) MembershipsByRole(client *Client, admins bool) ([]*WorkspaceMembership, error) {
+	memberships, err := w.AllWorkspaceMemberships(client, &Options{
+		Fields: []string{"user.name", "user.email", "is_active", "is_admin", "is_guest"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*WorkspaceMembership
+	for _, membership := range memberships {
+		if (admins && membership.IsAdmin) || (!admins && membership.IsGuest) {
+			result = append(result, membership)
+		}
+	}
+	return result, nil
+}
+
+// GID returns the globally unique ID of this WorkspaceMembership
+func (m *WorkspaceMembership) GID() string {
+	return m.ID
+}
+
+// Type returns the resource type of this WorkspaceMembership
+func (m *WorkspaceMembership) Type() string {
+	if m.ResourceType != "" {
+		return m.ResourceType
+	}
+	return "workspace_membership"
+}
@@ -23,24 +23,73 @@ type Workspace struct {
 	// Read-only. Globally unique ID of the object
 	ID string `json:"gid,omitempty"`
 
+	// Read-only. The base type of this resource
+	ResourceType string `json:"resource_type,omitempty"`
+
 	// Read-only. The name of the object.
 	Name string `json:"name,omitempty"`
 
 	// Whether the workspace is an organization.
 	IsOrganization bool `json:"is_organization,omitempty"`
 
-	// Undocumented in API docs
+	// Read-only. Opt In. The email domains that are associated with this
+	// workspace. Users with an address on one of these domains can join the
+	// organization without an invitation.
 	EmailDomains []string `json:"email_domains,omitempty"`
 }
 
 // Fetch loads the full details for this Workspace
-func (w *Workspace) Fetch(client *Client) error {
+func (w *Workspace) Fetch(client *Client, opts ...*Options) error {
 	client.trace("Loading details for workspace %s\n", w.ID)
 
-	_, err := client.get(fmt.Sprintf("/workspaces/%s", w.ID), nil, w)
+	_, err := client.get(fmt.Sprintf("/workspaces/%s", w.ID), nil, w, opts...)
 	return err
 }
 
+// FetchEmailDomains loads the email domains associated with this workspace.
+//
+// The API only allows the email domains to be read. They are managed by
+// organization admins in the Asana admin console.
+func (w *Workspace) FetchEmailDomains(client *Client) ([]string, error) {
+	client.trace("Loading email domains for workspace %s\n", w.ID)
+
+	result := &Workspace{}
+	_, err := client.get(fmt.Sprintf("/workspaces/%s", w.ID), nil, result, &Options{
+		Fields: []string{"email_domains"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	w.EmailDomains = result.EmailDomains
+	return w.EmailDomains, nil
+}
+
+// IsPremium makes a best-effort check of whether this workspace is on a paid
+// plan, so that applications can hide features which would fail with a
+// PremiumRequiredError.
+//
+// The API does not expose the plan of a workspace. Instead this makes a
+// minimal task search, which is only available on premium plans, and reports
+// whether it was rejected with 402 Payment Required. Other errors are
+// returned unchanged. Features which need a higher tier than premium, such as
+// portfolios, may still be unavailable when this returns true.
+func (w *Workspace) IsPremium(client *Client) (bool, error) {
+	client.trace("Checking plan of workspace %s\n", w.ID)
+
+	_, err := client.get(fmt.Sprintf("/workspaces/%s/tasks/search", w.ID), nil, nil, &Options{
+		Limit:  1,
+		Fields: []string{"gid"},
+	})
+	if IsPremiumRequired(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Workspaces returns workspaces and organizations accessible to the currently
 // authorized account
 func (c *Client) Workspaces(options ...*Options) ([]*Workspace, *NextPage, error) {
@@ -54,25 +103,18 @@ func (c *Client) Workspaces(options ...*Options) ([]*Workspace, *NextPage, error
 
 // AllWorkspaces repeatedly pages through all available workspaces for a client
 func (c *Client) AllWorkspaces(options ...*Options) ([]*Workspace, error) {
-	allWorkspaces := []*Workspace{}
-	nextPage := &NextPage{}
-
-	var workspaces []*Workspace
-	var err error
-
-	for nextPage != nil {
-		page := &Options{
-			Limit:  100,
-			Offset: nextPage.Offset,
-		}
+	return listAll(c, 100, c.Workspaces, options...)
+}
 
-		allOptions := append([]*Options{page}, options...)
-		workspaces, nextPage, err = c.Workspaces(allOptions...)
-		if err != nil {
-			return nil, err
-		}
+// GID returns the globally unique ID of this Workspace
+func (w *Workspace) GID() string {
+	return w.ID
+}
 
-		allWorkspaces = append(allWorkspaces, workspaces...)
+// Type returns the resource type of this Workspace
+func (w *Workspace) Type() string {
+	if w.ResourceType != "" {
+		return w.ResourceType
 	}
-	return allWorkspaces, nil
+	return "workspace"
 }